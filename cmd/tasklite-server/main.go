@@ -0,0 +1,116 @@
+// Command tasklite-server serves the TaskLite task API, to try the provider and run the
+// acceptance tests without TechChallengeApp or any other service.
+//
+// Tasks are kept in memory, or in the JSON file given with -data so that they survive
+// restarts:
+//
+//	go run ./cmd/tasklite-server -addr 127.0.0.1:3000 -data tasks.json
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"io/fs"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
+
+	"terraform-provider-tasklite/internal/task/fakeserver"
+)
+
+func main() {
+	addr := flag.String("addr", "127.0.0.1:3000", "address to listen on")
+	data := flag.String("data", "", "JSON file keeping the tasks; tasks are kept in memory when empty")
+	flag.Parse()
+
+	handler, err := newHandler(fakeserver.NewAPI(), *data)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	server := &http.Server{Addr: *addr, Handler: handler, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	log.Printf("Serving the TaskLite API on http://%s", *addr)
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatal(err.Error())
+	}
+}
+
+// newHandler serves api, logging every request. When path is set, the tasks are loaded from
+// path, if it exists, and saved to it after every request changing them.
+func newHandler(api *fakeserver.API, path string) (http.Handler, error) {
+	store := &fileStore{path: path}
+	if path != "" {
+		if err := store.load(api); err != nil {
+			return nil, err
+		}
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		log.Printf("%s %s", r.Method, r.URL.Path)
+		api.ServeHTTP(w, r)
+		if path == "" || r.Method == http.MethodGet || r.Method == http.MethodHead {
+			return
+		}
+		if err := store.save(api); err != nil {
+			log.Printf("Failed to save the tasks to %s: %s", path, err)
+		}
+	}), nil
+}
+
+// fileStore keeps the tasks of an API in a JSON file.
+type fileStore struct {
+	path string
+	// mu serialises saves, so that the file always ends up with the latest tasks
+	mu sync.Mutex
+}
+
+func (s *fileStore) load(api *fakeserver.API) error {
+	f, err := os.Open(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return api.Load(f)
+}
+
+// save replaces the file atomically, so that it is never left half written.
+func (s *fileStore) save(api *fakeserver.API) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if err := api.Save(f); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), s.path)
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"terraform-provider-tasklite/internal/task"
+	"terraform-provider-tasklite/internal/task/fakeserver"
+)
+
+func TestHandlerInMemory(t *testing.T) {
+	handler, err := newHandler(fakeserver.NewAPI(), "")
+	assert.NoError(t, err)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	created, err := task.NewClient(server.URL).CreateTask(context.Background(), task.Task{Title: "Task"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), created.ID)
+}
+
+func TestHandlerFileStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tasks.json")
+
+	handler, err := newHandler(fakeserver.NewAPI(), path)
+	assert.NoError(t, err)
+	server := httptest.NewServer(handler)
+	client := task.NewClient(server.URL)
+	_, err = client.CreateTask(context.Background(), task.Task{Title: "Kept task", Priority: 3})
+	assert.NoError(t, err)
+	_, err = client.CreateTask(context.Background(), task.Task{Title: "Deleted task"})
+	assert.NoError(t, err)
+	assert.NoError(t, client.DeleteTask(context.Background(), 2))
+	server.Close()
+
+	// a restarted server serves the saved tasks
+	handler, err = newHandler(fakeserver.NewAPI(), path)
+	assert.NoError(t, err)
+	server = httptest.NewServer(handler)
+	defer server.Close()
+	tasks, err := task.NewClient(server.URL).ListTasks(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []task.Task{{ID: 1, Title: "Kept task", Priority: 3}}, tasks)
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1, "temporary files are removed")
+}
+
+func TestHandlerInvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tasks.json")
+	assert.NoError(t, os.WriteFile(path, []byte("not json"), 0o600))
+
+	_, err := newHandler(fakeserver.NewAPI(), path)
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,2 @@
+# Tasks can be imported by specifying the numeric identifier.
+terraform import tasklite_task.example 1
@@ -0,0 +1,7 @@
+# Tasks can also be adopted with an import block. Run
+# `terraform plan -generate-config-out=generated.tf` to generate the
+# matching resource configuration.
+import {
+  to = tasklite_task.example
+  id = "1"
+}
@@ -0,0 +1,314 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-tasklite/internal/task"
+)
+
+// valueOrEnv returns the configured value, or the environment variable env when it is null.
+func valueOrEnv(v types.String, env string) string {
+	if !v.IsNull() {
+		return v.ValueString()
+	}
+	return os.Getenv(env)
+}
+
+// secretValues returns the configured credentials, which must never be logged.
+func secretValues(config hashicupsProviderModel) []string {
+	var secrets []string
+	for _, v := range []string{valueOrEnv(config.Token, "TASKLITE_TOKEN"), valueOrEnv(config.Password, "TASKLITE_PASSWORD"), config.ClientKeyPEM.ValueString()} {
+		if v != "" {
+			secrets = append(secrets, v)
+		}
+	}
+	if config.OAuth2 != nil && config.OAuth2.ClientSecret.ValueString() != "" {
+		secrets = append(secrets, config.OAuth2.ClientSecret.ValueString())
+	}
+	for _, v := range config.Headers.Elements() {
+		if s, ok := v.(types.String); ok && s.ValueString() != "" {
+			secrets = append(secrets, s.ValueString())
+		}
+	}
+	return secrets
+}
+
+// apiHosts returns the configured TaskLite API hosts, from the hosts or host attributes,
+// or else the TASKLITE_HOSTS or TASKLITE_HOST environment variables.
+func apiHosts(ctx context.Context, config hashicupsProviderModel) ([]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var hosts []string
+
+	switch {
+	case !config.Hosts.IsNull():
+		diags.Append(config.Hosts.ElementsAs(ctx, &hosts, false)...)
+	case !config.Host.IsNull():
+		hosts = []string{config.Host.ValueString()}
+	case os.Getenv("TASKLITE_HOSTS") != "":
+		for _, h := range strings.Split(os.Getenv("TASKLITE_HOSTS"), ",") {
+			if h = strings.TrimSpace(h); h != "" {
+				hosts = append(hosts, h)
+			}
+		}
+	default:
+		hosts = []string{os.Getenv("TASKLITE_HOST")}
+	}
+
+	if len(hosts) == 1 && hosts[0] == "" {
+		return nil, diags
+	}
+	return hosts, diags
+}
+
+// retryOptions maps the retry attributes to client options.
+func retryOptions(_ context.Context, config hashicupsProviderModel) ([]task.Option, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	retryPolicy := task.DefaultRetryPolicy()
+	if !config.MaxRetries.IsNull() {
+		retryPolicy.MaxRetries = int(config.MaxRetries.ValueInt64())
+	}
+	if !config.RetryMaxWait.IsNull() {
+		maxWait, err := time.ParseDuration(config.RetryMaxWait.ValueString())
+		if err != nil || maxWait <= 0 {
+			diags.AddAttributeError(
+				path.Root("retry_max_wait"),
+				"Invalid Retry Max Wait",
+				fmt.Sprintf("Expected a positive duration such as \"30s\", got: %q", config.RetryMaxWait.ValueString()),
+			)
+			return nil, diags
+		}
+		retryPolicy.MaxBackoff = maxWait
+	}
+
+	return []task.Option{task.WithRetryPolicy(retryPolicy)}, diags
+}
+
+// timeoutOptions maps the request_timeout attribute to client options.
+func timeoutOptions(_ context.Context, config hashicupsProviderModel) ([]task.Option, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if config.RequestTimeout.IsNull() {
+		return nil, diags
+	}
+
+	timeout, err := time.ParseDuration(config.RequestTimeout.ValueString())
+	if err != nil || timeout <= 0 {
+		diags.AddAttributeError(
+			path.Root("request_timeout"),
+			"Invalid Request Timeout",
+			fmt.Sprintf("Expected a positive duration such as \"60s\", got: %q", config.RequestTimeout.ValueString()),
+		)
+		return nil, diags
+	}
+
+	return []task.Option{task.WithRequestTimeout(timeout)}, diags
+}
+
+// rateLimitOptions maps the rate limiting attributes to client options.
+func rateLimitOptions(_ context.Context, config hashicupsProviderModel) ([]task.Option, diag.Diagnostics) {
+	var opts []task.Option
+	if !config.RequestsPerSecond.IsNull() {
+		opts = append(opts, task.WithRateLimit(config.RequestsPerSecond.ValueFloat64()))
+	}
+	if !config.MaxConcurrentRequests.IsNull() {
+		opts = append(opts, task.WithMaxConcurrentRequests(int(config.MaxConcurrentRequests.ValueInt64())))
+	}
+	return opts, nil
+}
+
+// circuitBreakerOptions maps the circuit breaker attributes to client options.
+func circuitBreakerOptions(_ context.Context, config hashicupsProviderModel) ([]task.Option, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if config.CircuitBreakerThreshold.IsNull() && config.CircuitBreakerCooldown.IsNull() {
+		return nil, diags
+	}
+
+	threshold := task.DefaultCircuitBreakerThreshold
+	if !config.CircuitBreakerThreshold.IsNull() {
+		threshold = int(config.CircuitBreakerThreshold.ValueInt64())
+	}
+	cooldown := task.DefaultCircuitBreakerCooldown
+	if !config.CircuitBreakerCooldown.IsNull() {
+		var err error
+		cooldown, err = time.ParseDuration(config.CircuitBreakerCooldown.ValueString())
+		if err != nil || cooldown <= 0 {
+			diags.AddAttributeError(
+				path.Root("circuit_breaker_cooldown"),
+				"Invalid Circuit Breaker Cooldown",
+				fmt.Sprintf("Expected a positive duration such as \"30s\", got: %q", config.CircuitBreakerCooldown.ValueString()),
+			)
+			return nil, diags
+		}
+	}
+
+	return []task.Option{task.WithCircuitBreaker(threshold, cooldown)}, diags
+}
+
+// endpointOptions maps the hosts and related attributes to client options.
+func endpointOptions(ctx context.Context, config hashicupsProviderModel) ([]task.Option, diag.Diagnostics) {
+	hosts, diags := apiHosts(ctx, config)
+	if len(hosts) < 2 {
+		return nil, diags
+	}
+
+	opts := []task.Option{task.WithFailoverEndpoints(hosts[1:]...)}
+	if config.HostSelection.ValueString() == "round_robin" {
+		opts = append(opts, task.WithRoundRobin())
+	}
+	if !config.HealthCheckPath.IsNull() {
+		opts = append(opts, task.WithHealthCheckPath(config.HealthCheckPath.ValueString()))
+	}
+	return opts, diags
+}
+
+// authOptions maps the authentication attributes to client options.
+func authOptions(ctx context.Context, config hashicupsProviderModel) ([]task.Option, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var opts []task.Option
+
+	if !config.Headers.IsNull() {
+		headers := map[string]string{}
+		diags.Append(config.Headers.ElementsAs(ctx, &headers, false)...)
+		opts = append(opts, task.WithAuthenticator(task.HeadersAuth(headers)))
+		// the headers are sensitive, so their values are never logged nor recorded
+		names := make([]string, 0, len(headers))
+		for name := range headers {
+			names = append(names, name)
+		}
+		opts = append(opts, task.WithRedactedHeaders(names...))
+	}
+
+	token := valueOrEnv(config.Token, "TASKLITE_TOKEN")
+	username := valueOrEnv(config.Username, "TASKLITE_USERNAME")
+	password := valueOrEnv(config.Password, "TASKLITE_PASSWORD")
+
+	if token != "" && username != "" {
+		diags.AddAttributeError(
+			path.Root("token"),
+			"Conflicting TaskLite Credentials",
+			"Both a token and a username were provided. Configure either token authentication or basic authentication, "+
+				"including via the TASKLITE_TOKEN and TASKLITE_USERNAME environment variables.",
+		)
+		return nil, diags
+	}
+
+	if config.OAuth2 != nil && (token != "" || username != "") {
+		diags.AddAttributeError(
+			path.Root("oauth2"),
+			"Conflicting TaskLite Credentials",
+			"OAuth2 authentication cannot be combined with token or basic authentication, "+
+				"including via the TASKLITE_TOKEN and TASKLITE_USERNAME environment variables.",
+		)
+		return nil, diags
+	}
+
+	if (username == "") != (password == "") {
+		diags.AddAttributeError(
+			path.Root("username"),
+			"Incomplete TaskLite Basic Authentication",
+			"Basic authentication requires both a username and a password. "+
+				"Set both in the configuration or via the TASKLITE_USERNAME and TASKLITE_PASSWORD environment variables.",
+		)
+		return nil, diags
+	}
+
+	switch {
+	case config.OAuth2 != nil:
+		required := []struct {
+			name  string
+			value types.String
+		}{
+			{"token_url", config.OAuth2.TokenURL},
+			{"client_id", config.OAuth2.ClientID},
+			{"client_secret", config.OAuth2.ClientSecret},
+		}
+		for _, r := range required {
+			if r.value.ValueString() == "" {
+				diags.AddAttributeError(
+					path.Root("oauth2").AtName(r.name),
+					"Missing OAuth2 Configuration",
+					fmt.Sprintf("The oauth2 block requires a non-empty %s.", r.name),
+				)
+			}
+		}
+		if diags.HasError() {
+			return nil, diags
+		}
+		cfg := task.OAuth2Config{
+			TokenURL:     config.OAuth2.TokenURL.ValueString(),
+			ClientID:     config.OAuth2.ClientID.ValueString(),
+			ClientSecret: config.OAuth2.ClientSecret.ValueString(),
+			Audience:     config.OAuth2.Audience.ValueString(),
+		}
+		diags.Append(config.OAuth2.Scopes.ElementsAs(ctx, &cfg.Scopes, false)...)
+		opts = append(opts, task.WithOAuth2(cfg))
+	case token != "":
+		opts = append(opts, task.WithAuthenticator(task.BearerTokenAuth(token)))
+	case username != "":
+		opts = append(opts, task.WithAuthenticator(task.BasicAuth(username, password)))
+	}
+
+	return opts, diags
+}
+
+// tlsOptions maps the TLS attributes to client options.
+func tlsOptions(_ context.Context, config hashicupsProviderModel) ([]task.Option, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	tlsOpts := task.TLSOptions{
+		CACertPEM:          []byte(config.CACertPEM.ValueString()),
+		ClientCertPEM:      []byte(config.ClientCertPEM.ValueString()),
+		ClientKeyPEM:       []byte(config.ClientKeyPEM.ValueString()),
+		ServerName:         config.TLSServerName.ValueString(),
+		InsecureSkipVerify: config.InsecureSkipVerify.ValueBool(),
+	}
+
+	if !config.CACertFile.IsNull() {
+		pem, err := os.ReadFile(config.CACertFile.ValueString())
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("ca_cert_file"),
+				"Unreadable CA Certificate File",
+				fmt.Sprintf("The CA certificate file could not be read: %s", err),
+			)
+			return nil, diags
+		}
+		tlsOpts.CACertPEM = pem
+	}
+
+	if len(tlsOpts.CACertPEM) == 0 && len(tlsOpts.ClientCertPEM) == 0 && tlsOpts.ServerName == "" && !tlsOpts.InsecureSkipVerify {
+		return nil, diags
+	}
+
+	cfg, err := tlsOpts.Config()
+	if err != nil {
+		diags.AddError(
+			"Invalid TLS Configuration",
+			fmt.Sprintf("The provider cannot create the TaskLite API client TLS configuration: %s", err),
+		)
+		return nil, diags
+	}
+
+	return []task.Option{task.WithTLSConfig(cfg)}, diags
+}
+
+// logOptions maps the logging attributes to client options.
+func logOptions(ctx context.Context, config hashicupsProviderModel) ([]task.Option, diag.Diagnostics) {
+	if config.RedactedLogFields.IsNull() {
+		return nil, nil
+	}
+
+	var fields []string
+	diags := config.RedactedLogFields.ElementsAs(ctx, &fields, false)
+	return []task.Option{task.WithRedactedLogFields(fields...)}, diags
+}
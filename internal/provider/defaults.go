@@ -0,0 +1,114 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ planmodifier.Int32  = int32DefaultModifier{}
+	_ planmodifier.Bool   = boolDefaultModifier{}
+	_ planmodifier.String = titlePrefixModifier{}
+)
+
+// int32DefaultModifier plans the default value of the provider for an attribute omitted
+// from the configuration. Attributes keep their schema default when the provider sets none.
+type int32DefaultModifier struct {
+	value types.Int32
+}
+
+func (m int32DefaultModifier) Description(_ context.Context) string {
+	return fmt.Sprintf("defaults to %s, as configured by the provider", m.value)
+}
+
+func (m int32DefaultModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m int32DefaultModifier) PlanModifyInt32(_ context.Context, req planmodifier.Int32Request, resp *planmodifier.Int32Response) {
+	if !req.ConfigValue.IsNull() || m.value.IsNull() || m.value.IsUnknown() {
+		return
+	}
+	resp.PlanValue = m.value
+}
+
+// boolDefaultModifier plans the default value of the provider for an attribute omitted
+// from the configuration. Attributes keep their schema default when the provider sets none.
+type boolDefaultModifier struct {
+	value types.Bool
+}
+
+func (m boolDefaultModifier) Description(_ context.Context) string {
+	return fmt.Sprintf("defaults to %s, as configured by the provider", m.value)
+}
+
+func (m boolDefaultModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m boolDefaultModifier) PlanModifyBool(_ context.Context, req planmodifier.BoolRequest, resp *planmodifier.BoolResponse) {
+	if !req.ConfigValue.IsNull() || m.value.IsNull() || m.value.IsUnknown() {
+		return
+	}
+	resp.PlanValue = m.value
+}
+
+// titlePrefixModifier plans the full title of a task: its planned title, with the title
+// prefix of the provider.
+type titlePrefixModifier struct {
+	prefix string
+}
+
+func (m titlePrefixModifier) Description(_ context.Context) string {
+	return fmt.Sprintf("title prefixed with %q, as configured by the provider", m.prefix)
+}
+
+func (m titlePrefixModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m titlePrefixModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	var title types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("title"), &title)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if title.IsUnknown() {
+		resp.PlanValue = types.StringUnknown()
+		return
+	}
+	resp.PlanValue = types.StringValue(m.prefix + title.ValueString())
+}
+
+// planDefaults applies the defaults of the provider to the plan of a task. They depend on
+// the configuration of the provider, so they cannot be set as plan modifiers of the
+// schema, which is shared by all the configurations of the provider.
+func (r *taskResource) planDefaults(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	priority := planmodifier.Int32Request{Path: path.Root("priority"), Config: req.Config, Plan: resp.Plan, State: req.State}
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, priority.Path, &priority.ConfigValue)...)
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, priority.Path, &priority.PlanValue)...)
+	priorityResp := planmodifier.Int32Response{PlanValue: priority.PlanValue}
+	int32DefaultModifier{value: r.defaults.Priority}.PlanModifyInt32(ctx, priority, &priorityResp)
+	resp.Diagnostics.Append(priorityResp.Diagnostics...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, priority.Path, priorityResp.PlanValue)...)
+
+	complete := planmodifier.BoolRequest{Path: path.Root("complete"), Config: req.Config, Plan: resp.Plan, State: req.State}
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, complete.Path, &complete.ConfigValue)...)
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, complete.Path, &complete.PlanValue)...)
+	completeResp := planmodifier.BoolResponse{PlanValue: complete.PlanValue}
+	boolDefaultModifier{value: r.defaults.Complete}.PlanModifyBool(ctx, complete, &completeResp)
+	resp.Diagnostics.Append(completeResp.Diagnostics...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, complete.Path, completeResp.PlanValue)...)
+
+	fullTitle := planmodifier.StringRequest{Path: path.Root("full_title"), Config: req.Config, Plan: resp.Plan, State: req.State}
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, fullTitle.Path, &fullTitle.PlanValue)...)
+	fullTitleResp := planmodifier.StringResponse{PlanValue: fullTitle.PlanValue}
+	titlePrefixModifier{prefix: r.defaults.TitlePrefix.ValueString()}.PlanModifyString(ctx, fullTitle, &fullTitleResp)
+	resp.Diagnostics.Append(fullTitleResp.Diagnostics...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, fullTitle.Path, fullTitleResp.PlanValue)...)
+}
@@ -0,0 +1,158 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/stretchr/testify/assert"
+
+	"terraform-provider-tasklite/internal/task"
+	"terraform-provider-tasklite/internal/task/tasklitetest"
+)
+
+func TestInt32DefaultModifier(t *testing.T) {
+	for _, tc := range []struct {
+		value, config, want types.Int32
+	}{
+		{types.Int32Value(3), types.Int32Null(), types.Int32Value(3)},
+		{types.Int32Value(3), types.Int32Value(1), types.Int32Value(0)},
+		{types.Int32Null(), types.Int32Null(), types.Int32Value(0)},
+	} {
+		resp := planmodifier.Int32Response{PlanValue: types.Int32Value(0)}
+		int32DefaultModifier{value: tc.value}.PlanModifyInt32(context.Background(), planmodifier.Int32Request{ConfigValue: tc.config}, &resp)
+
+		assert.Equal(t, tc.want, resp.PlanValue, "default %s, configured %s", tc.value, tc.config)
+	}
+}
+
+func TestBoolDefaultModifier(t *testing.T) {
+	for _, tc := range []struct {
+		value, config, want types.Bool
+	}{
+		{types.BoolValue(true), types.BoolNull(), types.BoolValue(true)},
+		{types.BoolValue(true), types.BoolValue(false), types.BoolValue(false)},
+		{types.BoolNull(), types.BoolNull(), types.BoolValue(false)},
+	} {
+		resp := planmodifier.BoolResponse{PlanValue: types.BoolValue(false)}
+		boolDefaultModifier{value: tc.value}.PlanModifyBool(context.Background(), planmodifier.BoolRequest{ConfigValue: tc.config}, &resp)
+
+		assert.Equal(t, tc.want, resp.PlanValue, "default %s, configured %s", tc.value, tc.config)
+	}
+}
+
+func TestTaskResourceModelTitles(t *testing.T) {
+	var m taskResourceModel
+	m.setTask(&task.Task{ID: 1, Title: "[ops] Task"}, "[ops] ")
+	assert.Equal(t, types.StringValue("Task"), m.Title)
+	assert.Equal(t, types.StringValue("[ops] Task"), m.FullTitle)
+	assert.Equal(t, types.StringValue("[ops] Task"), m.apiTaskModel().Title)
+
+	// tasks titled outside of Terraform keep their title
+	m.setTask(&task.Task{ID: 1, Title: "Task"}, "[ops] ")
+	assert.Equal(t, types.StringValue("Task"), m.Title)
+	assert.Equal(t, types.StringValue("Task"), m.FullTitle)
+
+	// the state of tasks created before full_title existed
+	m.FullTitle = types.StringNull()
+	assert.Equal(t, types.StringValue("Task"), m.apiTaskModel().Title)
+}
+
+func TestTaskResourceConfigureDefaults(t *testing.T) {
+	r := &taskResource{}
+	r.Configure(context.Background(), fwresource.ConfigureRequest{}, &fwresource.ConfigureResponse{})
+	assert.False(t, r.configured)
+
+	// the defaults do not depend on the client
+	defaults := taskDefaultsModel{Priority: types.Int32Value(3), Complete: types.BoolNull(), TitlePrefix: types.StringValue("[ops] ")}
+	resp := &fwresource.ConfigureResponse{}
+	r.Configure(context.Background(), fwresource.ConfigureRequest{ProviderData: &taskResourceData{defaults: defaults}}, resp)
+	assert.Empty(t, resp.Diagnostics)
+	assert.True(t, r.configured)
+	assert.Equal(t, defaults, r.defaults)
+}
+
+func TestAccTaskResourceDefaults(t *testing.T) {
+	server := tasklitetest.NewServer(t)
+	resourceName := "tasklite_task.test"
+	config := func(defaults, attributes string) string {
+		return fmt.Sprintf(`
+provider "tasklite" {
+  host = "%s"
+
+  defaults {
+    %s
+  }
+}
+
+resource "tasklite_task" "test" {
+  title = "Task"
+  %s
+}
+`, server.URL, defaults, attributes)
+	}
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("priority = 3\n    complete = true\n    title_prefix = \"[ops] \"", ""),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					// the plan shows the inherited values
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectKnownValue(resourceName, tfjsonpath.New("priority"), knownvalue.Int32Exact(3)),
+						plancheck.ExpectKnownValue(resourceName, tfjsonpath.New("complete"), knownvalue.Bool(true)),
+						plancheck.ExpectKnownValue(resourceName, tfjsonpath.New("full_title"), knownvalue.StringExact("[ops] Task")),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "title", "Task"),
+					resource.TestCheckResourceAttr(resourceName, "full_title", "[ops] Task"),
+					resource.TestCheckResourceAttr(resourceName, "priority", "3"),
+					resource.TestCheckResourceAttr(resourceName, "complete", "true"),
+					func(_ *terraform.State) error {
+						if got, ok := server.Task(1); !ok || got.Title != "[ops] Task" || got.Priority != 3 || !got.Complete {
+							return fmt.Errorf("unexpected task in TaskLite: %+v", got)
+						}
+						return nil
+					},
+				),
+			},
+			// the resource overrides the defaults
+			{
+				Config: config("priority = 3\n    complete = true\n    title_prefix = \"[ops] \"", "priority = 1"),
+				Check:  resource.TestCheckResourceAttr(resourceName, "priority", "1"),
+			},
+			// changing the defaults updates the tasks inheriting them only
+			{
+				Config: config("priority = 5\n    title_prefix = \"[dev] \"", "priority = 1"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectKnownValue(resourceName, tfjsonpath.New("priority"), knownvalue.Int32Exact(1)),
+						plancheck.ExpectKnownValue(resourceName, tfjsonpath.New("complete"), knownvalue.Bool(false)),
+						plancheck.ExpectKnownValue(resourceName, tfjsonpath.New("full_title"), knownvalue.StringExact("[dev] Task")),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "title", "Task"),
+					resource.TestCheckResourceAttr(resourceName, "full_title", "[dev] Task"),
+					resource.TestCheckResourceAttr(resourceName, "complete", "false"),
+				),
+			},
+			{
+				Config:            config("priority = 5\n    title_prefix = \"[dev] \"", "priority = 1"),
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     "1",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
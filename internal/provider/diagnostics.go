@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+
+	"terraform-provider-tasklite/internal/task"
+)
+
+// taskAttributes are the task attributes an API error may point at.
+var taskAttributes = map[string]bool{
+	"title":    true,
+	"priority": true,
+	"complete": true,
+}
+
+// addErrorDiagnostic adds an error diagnostic for a failed task operation. When err is a
+// task.APIError the summary reflects the status code, and the diagnostic is attached to
+// the attribute named by the server, if any.
+func addErrorDiagnostic(diags *diag.Diagnostics, operation string, err error) {
+	summary := fmt.Sprintf("%s Operation Error", operation)
+	detail := fmt.Sprintf("Failed to %s the task, got error: %s", operation, err)
+
+	var timeoutErr *task.TimeoutError
+	if errors.As(err, &timeoutErr) {
+		diags.AddError(
+			fmt.Sprintf("%s Operation Timed Out", operation),
+			detail+"\n\nIncrease the resource timeouts block, or the provider request_timeout when single requests are slow.",
+		)
+		return
+	}
+
+	if errors.Is(err, task.ErrCircuitOpen) {
+		diags.AddError(
+			"TaskLite Unavailable",
+			detail+"\n\nThe request was not sent because earlier requests failed repeatedly. "+
+				"Check that TaskLite is reachable, or adjust the provider circuit_breaker_threshold and circuit_breaker_cooldown.",
+		)
+		return
+	}
+
+	var apiErr *task.APIError
+	if !errors.As(err, &apiErr) {
+		diags.AddError(summary, detail)
+		return
+	}
+
+	switch apiErr.StatusCode {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		summary = "Invalid Task"
+	case http.StatusUnauthorized, http.StatusForbidden:
+		summary = "TaskLite Authorization Error"
+		detail += "\n\nEnsure the provider is configured with credentials allowed to manage tasks."
+	case http.StatusNotFound:
+		summary = "Task Not Found"
+	case http.StatusConflict:
+		summary = "Task Conflict"
+	case http.StatusPreconditionFailed:
+		summary = "Task Changed Outside Terraform"
+		detail += "\n\nThe task was modified in TaskLite since Terraform last read it. " +
+			"Refresh the state, e.g. with terraform apply -refresh-only, review the changes and apply again."
+	}
+
+	if taskAttributes[apiErr.Field] {
+		diags.AddAttributeError(path.Root(apiErr.Field), summary, detail)
+		return
+	}
+
+	diags.AddError(summary, detail)
+}
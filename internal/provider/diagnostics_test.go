@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/stretchr/testify/assert"
+
+	"terraform-provider-tasklite/internal/task"
+)
+
+func TestAddErrorDiagnostic(t *testing.T) {
+	fieldErr := &task.APIError{StatusCode: http.StatusUnprocessableEntity, Message: "title too long", Field: "title"}
+	unknownFieldErr := &task.APIError{StatusCode: http.StatusConflict, Message: "duplicate", Field: "owner"}
+	plainErr := errors.New("connection refused")
+	staleErr := &task.APIError{StatusCode: http.StatusPreconditionFailed, Method: http.MethodPut, URL: "http://tasklite/api/task/1/"}
+	circuitErr := &task.CircuitOpenError{Failures: 5, LastError: "503 Service Unavailable"}
+	timeoutErr := &task.TimeoutError{Method: http.MethodPost, URL: "http://tasklite/api/task/", Elapsed: time.Second, Err: context.DeadlineExceeded}
+
+	tests := []struct {
+		name     string
+		err      error
+		expected diag.Diagnostic
+	}{
+		{
+			name:     "api error with known field",
+			err:      fieldErr,
+			expected: diag.NewAttributeErrorDiagnostic(path.Root("title"), "Invalid Task", "Failed to Create the task, got error: "+fieldErr.Error()),
+		},
+		{
+			name:     "api error with unknown field",
+			err:      unknownFieldErr,
+			expected: diag.NewErrorDiagnostic("Task Conflict", "Failed to Create the task, got error: "+unknownFieldErr.Error()),
+		},
+		{
+			name:     "non api error",
+			err:      plainErr,
+			expected: diag.NewErrorDiagnostic("Create Operation Error", "Failed to Create the task, got error: connection refused"),
+		},
+		{
+			name: "precondition failed error",
+			err:  staleErr,
+			expected: diag.NewErrorDiagnostic(
+				"Task Changed Outside Terraform",
+				"Failed to Create the task, got error: PUT http://tasklite/api/task/1/: HTTP 412: "+
+					"\n\nThe task was modified in TaskLite since Terraform last read it. "+
+					"Refresh the state, e.g. with terraform apply -refresh-only, review the changes and apply again.",
+			),
+		},
+		{
+			name: "circuit open error",
+			err:  circuitErr,
+			expected: diag.NewErrorDiagnostic(
+				"TaskLite Unavailable",
+				"Failed to Create the task, got error: "+circuitErr.Error()+
+					"\n\nThe request was not sent because earlier requests failed repeatedly. "+
+					"Check that TaskLite is reachable, or adjust the provider circuit_breaker_threshold and circuit_breaker_cooldown.",
+			),
+		},
+		{
+			name: "timeout error",
+			err:  timeoutErr,
+			expected: diag.NewErrorDiagnostic(
+				"Create Operation Timed Out",
+				"Failed to Create the task, got error: POST http://tasklite/api/task/ timed out after 1s: context deadline exceeded"+
+					"\n\nIncrease the resource timeouts block, or the provider request_timeout when single requests are slow.",
+			),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			addErrorDiagnostic(&diags, "Create", tt.err)
+			assert.Equal(t, diag.Diagnostics{tt.expected}, diags)
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"terraform-provider-tasklite/internal/task"
+)
+
+// metricsFileEnvVar is the file the metrics of the TaskLite requests are written to.
+const metricsFileEnvVar = "TASKLITE_METRICS_FILE"
+
+const (
+	// metricsLockTimeout bounds the wait for the other provider processes writing the
+	// metrics file, which Terraform stops at the same time.
+	metricsLockTimeout = 500 * time.Millisecond
+	// metricsStaleLockAge is the age past which the lock of the metrics file is assumed to
+	// be left by a killed process.
+	metricsStaleLockAge = 10 * time.Second
+)
+
+// InitMetrics returns the client options collecting metrics of the TaskLite requests when
+// TASKLITE_METRICS_FILE is set. The returned function adds them to the metrics already in
+// the file, in the Prometheus text format, and must be called before the provider exits.
+// Terraform runs a provider process per command, e.g. plan and apply, so the file sums
+// the requests of all of them.
+func InitMetrics() ([]task.Option, func() error) {
+	path := os.Getenv(metricsFileEnvVar)
+	if path == "" {
+		return nil, func() error { return nil }
+	}
+
+	metrics := task.NewPrometheusMetrics()
+	return []task.Option{task.WithMetrics(metrics)}, func() error {
+		if metrics.IsEmpty() {
+			return nil
+		}
+		return writeMetrics(path, metrics)
+	}
+}
+
+// writeMetrics merges metrics with those of the file at path, and replaces the file. The
+// file is locked meanwhile, so that provider processes exiting together add up their
+// metrics. A file whose metrics cannot be merged, e.g. written with other latency buckets,
+// is replaced with metrics alone, and the error is returned once they are written.
+func writeMetrics(path string, metrics *task.PrometheusMetrics) error {
+	unlock, err := lockFile(path + ".lock")
+	if err != nil {
+		return fmt.Errorf("failed to lock the metrics file: %w", err)
+	}
+	defer unlock()
+
+	var mergeErr error
+	previous, err := os.Open(path)
+	if err == nil {
+		mergeErr = errors.Join(metrics.Merge(previous), previous.Close())
+	} else if !errors.Is(err, os.ErrNotExist) {
+		mergeErr = err
+	}
+	if mergeErr != nil {
+		mergeErr = fmt.Errorf("could not merge the metrics file, replacing it with the metrics of this process: %w", mergeErr)
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return errors.Join(mergeErr, fmt.Errorf("failed to create the metrics file: %w", err))
+	}
+	_, err = metrics.WriteTo(f)
+	if err = errors.Join(err, f.Close()); err != nil {
+		_ = os.Remove(f.Name())
+		return errors.Join(mergeErr, fmt.Errorf("failed to write the metrics file: %w", err))
+	}
+	return errors.Join(mergeErr, os.Rename(f.Name(), path))
+}
+
+// lockFile creates the lock file at path, waiting up to metricsLockTimeout for another
+// process holding it, and returns the function releasing it.
+func lockFile(path string) (func(), error) {
+	deadline := time.Now().Add(metricsLockTimeout)
+	for {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+		if err == nil {
+			_ = f.Close()
+			return func() { _ = os.Remove(path) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, err
+		}
+		if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) > metricsStaleLockAge {
+			_ = os.Remove(path)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("%s is held by another process", path)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,147 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+
+	"terraform-provider-tasklite/internal/task"
+	"terraform-provider-tasklite/internal/task/tasklitetest"
+)
+
+func TestInitMetricsDisabled(t *testing.T) {
+	t.Setenv(metricsFileEnvVar, "")
+
+	opts, writeMetrics := InitMetrics()
+
+	assert.Empty(t, opts)
+	assert.NoError(t, writeMetrics())
+}
+
+func TestInitMetricsAccumulates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metrics.prom")
+	t.Setenv(metricsFileEnvVar, path)
+	server := tasklitetest.NewServer(t)
+	server.AddTask(task.Task{Title: "Existing task"})
+
+	// nothing is written by a process that made no request
+	_, writeMetrics := InitMetrics()
+	assert.NoError(t, writeMetrics())
+	assert.NoFileExists(t, path)
+
+	// e.g. the plan and the apply processes
+	for _, requests := range []int{2, 3} {
+		opts, writeMetrics := InitMetrics()
+		client := task.NewClient(server.URL, opts...)
+		for range requests {
+			_, err := client.ReadTask(context.Background(), 1)
+			assert.NoError(t, err)
+		}
+		assert.NoError(t, writeMetrics())
+	}
+
+	metrics, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Contains(t, string(metrics), `tasklite_client_requests_total{method="GET",status="200"} 5`+"\n")
+	assert.Contains(t, string(metrics), `tasklite_client_request_duration_seconds_count{method="GET"} 5`+"\n")
+}
+
+func TestWriteMetricsConcurrent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metrics.prom")
+
+	// e.g. the processes of several provider configurations, stopped together
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			metrics := task.NewPrometheusMetrics()
+			metrics.ObserveRequest(http.MethodGet, http.StatusOK, time.Millisecond)
+			assert.NoError(t, writeMetrics(path, metrics))
+		}()
+	}
+	wg.Wait()
+
+	metrics, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Contains(t, string(metrics), `tasklite_client_requests_total{method="GET",status="200"} 10`+"\n")
+	assert.NoFileExists(t, path+".lock")
+}
+
+func TestWriteMetricsUnmergeable(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metrics.prom")
+	other := task.NewPrometheusMetrics(1, 2)
+	other.ObserveRequest(http.MethodPost, http.StatusCreated, time.Second)
+	assert.NoError(t, writeMetrics(path, other))
+
+	metrics := task.NewPrometheusMetrics()
+	metrics.ObserveRequest(http.MethodGet, http.StatusOK, time.Millisecond)
+	err := writeMetrics(path, metrics)
+
+	// the metrics of this process are kept
+	assert.ErrorContains(t, err, "latency buckets differ")
+	written, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Contains(t, string(written), `tasklite_client_requests_total{method="GET",status="200"} 1`+"\n")
+	assert.NotContains(t, string(written), `method="POST"`)
+}
+
+func TestLockFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metrics.prom.lock")
+	unlock, err := lockFile(path)
+	assert.NoError(t, err)
+
+	_, err = lockFile(path)
+	assert.ErrorContains(t, err, "held by another process")
+
+	// the lock of a killed process is taken over
+	stale := time.Now().Add(-2 * metricsStaleLockAge)
+	assert.NoError(t, os.Chtimes(path, stale, stale))
+	unlockStale, err := lockFile(path)
+	assert.NoError(t, err)
+	unlockStale()
+	assert.NoFileExists(t, path)
+	unlock()
+}
+
+func TestAccProviderMetrics(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metrics.prom")
+	t.Setenv(metricsFileEnvVar, path)
+	opts, writeMetrics := InitMetrics()
+	server := tasklitetest.NewServer(t)
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"tasklite": providerserver.NewProtocol6WithError(New("test", opts...)()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "tasklite" {
+  host = "%s"
+}
+
+resource "tasklite_task" "test" {
+  title = "Measured task"
+}
+`, server.URL),
+			},
+		},
+	})
+
+	assert.NoError(t, writeMetrics())
+	metrics, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Contains(t, string(metrics), `tasklite_client_requests_total{method="POST",status="201"} 1`+"\n")
+	assert.Contains(t, string(metrics), `tasklite_client_requests_total{method="DELETE",status="204"} 1`+"\n")
+	assert.Contains(t, string(metrics), `tasklite_client_request_duration_seconds_count{method="POST"} 1`+"\n")
+}
@@ -2,13 +2,21 @@ package provider
 
 import (
 	"context"
-	"os"
+	"fmt"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"terraform-provider-tasklite/internal/task"
@@ -19,11 +27,13 @@ var (
 	_ provider.Provider = &taskLiteProvider{}
 )
 
-// New is a helper function to simplify provider server and testing implementation.
-func New(version string) func() provider.Provider {
+// New is a helper function to simplify provider server and testing implementation. The
+// client options are applied to the clients of every configuration of the provider.
+func New(version string, clientOptions ...task.Option) func() provider.Provider {
 	return func() provider.Provider {
 		return &taskLiteProvider{
-			version: version,
+			version:       version,
+			clientOptions: clientOptions,
 		}
 	}
 }
@@ -42,6 +52,193 @@ func (p *taskLiteProvider) Schema(_ context.Context, _ provider.SchemaRequest, r
 				Description: "URL for TaskLite API. May also be provided via TASKLITE_HOST environment variable.",
 				Optional:    true,
 			},
+			"hosts": schema.ListAttribute{
+				Description: "URLs of TaskLite API endpoints serving the same tasks, used instead of `host`. Reads and idempotent writes fail over to the next endpoint when one is unreachable or answers 502, 503 or 504. " +
+					"May also be provided as a comma-separated list via TASKLITE_HOSTS environment variable.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.List{
+					listvalidator.ConflictsWith(path.MatchRoot("host")),
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"host_selection": schema.StringAttribute{
+				Description: "How requests are spread across `hosts`: `ordered` sends every request to the first healthy host, `round_robin` rotates between healthy hosts. Default is ordered",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("ordered", "round_robin"),
+				},
+			},
+			"health_check_path": schema.StringAttribute{
+				Description: "Path requested on an unhealthy host to check whether it recovered. Any 2xx response marks the host healthy. Default is /api/version/, where a 404 from servers without version discovery also marks the host healthy",
+				Optional:    true,
+			},
+			"skip_health_check": schema.BoolAttribute{
+				Description: "Skip contacting TaskLite while configuring the provider. By default the provider checks that TaskLite is reachable with the configured credentials, and detects the API version and capabilities of the server.",
+				Optional:    true,
+			},
+			"max_retries": schema.Int64Attribute{
+				Description: "Maximum number of times a failed idempotent request is retried. Set to 0 to disable retries. Default is 3",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"retry_max_wait": schema.StringAttribute{
+				Description: "Maximum wait between retries as a duration, e.g. `30s`. Also caps waits requested by the server via Retry-After. Default is 30s",
+				Optional:    true,
+			},
+			"request_timeout": schema.StringAttribute{
+				Description: "Maximum duration of a single request attempt as a duration, e.g. `30s`. Operations are also bounded by the resource `timeouts` block. Default is 60s",
+				Optional:    true,
+			},
+			"requests_per_second": schema.Float64Attribute{
+				Description: "Maximum average number of requests per second sent to the TaskLite API, shared by all resources and data sources. Retries count as requests. Default is no limit",
+				Optional:    true,
+				Validators: []validator.Float64{
+					float64validator.AtLeast(0),
+				},
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				Description: "Maximum number of requests in flight to the TaskLite API at once, shared by all resources and data sources. Default is no limit",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"circuit_breaker_threshold": schema.Int64Attribute{
+				Description: "Number of consecutive connection failures or 5xx responses after which requests fail fast instead of being sent. Set to 0 to disable the circuit breaker. Default is 5",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"circuit_breaker_cooldown": schema.StringAttribute{
+				Description: "Duration requests fail fast once the circuit breaker opened, e.g. `30s`, before a request is sent to probe TaskLite. Default is 30s",
+				Optional:    true,
+			},
+			"token": schema.StringAttribute{
+				Description: "Bearer token used to authenticate with the TaskLite API. May also be provided via TASKLITE_TOKEN environment variable.",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"username": schema.StringAttribute{
+				Description: "Username for HTTP basic authentication. May also be provided via TASKLITE_USERNAME environment variable.",
+				Optional:    true,
+			},
+			"password": schema.StringAttribute{
+				Description: "Password for HTTP basic authentication. May also be provided via TASKLITE_PASSWORD environment variable.",
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"headers": schema.MapAttribute{
+				Description: "Additional HTTP headers sent with every request to the TaskLite API.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				Description: "PEM encoded CA certificates trusted, in addition to the system ones, to verify the TaskLite API certificate.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("ca_cert_file")),
+				},
+			},
+			"ca_cert_file": schema.StringAttribute{
+				Description: "Path to a file with PEM encoded CA certificates trusted, in addition to the system ones, to verify the TaskLite API certificate.",
+				Optional:    true,
+			},
+			"client_cert_pem": schema.StringAttribute{
+				Description: "PEM encoded client certificate used for mutual TLS authentication.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("client_key_pem")),
+				},
+			},
+			"client_key_pem": schema.StringAttribute{
+				Description: "PEM encoded private key of the client certificate.",
+				Optional:    true,
+				Sensitive:   true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("client_cert_pem")),
+				},
+			},
+			"tls_server_name": schema.StringAttribute{
+				Description: "Server name used to verify the TaskLite API certificate, when it differs from the host.",
+				Optional:    true,
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				Description: "Skip verification of the TaskLite API certificate. Only use for testing. Default is false",
+				Optional:    true,
+			},
+			"min_priority": schema.Int32Attribute{
+				Description: "Lowest task priority accepted by TaskLite. Tasks with lower priorities are rejected when planned. Default is no limit",
+				Optional:    true,
+			},
+			"max_priority": schema.Int32Attribute{
+				Description: "Highest task priority accepted by TaskLite. Tasks with higher priorities are rejected when planned. Default is no limit",
+				Optional:    true,
+			},
+			"strict_validation": schema.BoolAttribute{
+				Description: "Reject planned tasks whose priority is outside of `min_priority` and `max_priority`. When false, they are reported as warnings and left to TaskLite to accept or reject. " +
+					"Only applies to the priority range: invalid titles are always rejected. Default is true",
+				Optional: true,
+			},
+			"redacted_log_fields": schema.ListAttribute{
+				Description: "Names of JSON and form fields whose values are redacted from the request and response bodies logged at TRACE level, " +
+					"in addition to common credential fields such as `password` and `token`. Authorization and cookie headers are always redacted.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"defaults": schema.SingleNestedBlock{
+				Description: "Default attributes of the `tasklite_task` resources, used when a resource omits them.",
+				Attributes: map[string]schema.Attribute{
+					"priority": schema.Int32Attribute{
+						Description: "Priority of the tasks that do not set one. Default is 0",
+						Optional:    true,
+					},
+					"complete": schema.BoolAttribute{
+						Description: "Whether the tasks that do not set `complete` are complete. Default is false",
+						Optional:    true,
+					},
+					"title_prefix": schema.StringAttribute{
+						Description: "Prefix of the titles of the tasks in TaskLite. The `title` attribute of the resources omits it, and the `full_title` attribute includes it. " +
+							"The data sources return the titles as stored in TaskLite, prefix included.",
+						Optional: true,
+					},
+				},
+			},
+			"oauth2": schema.SingleNestedBlock{
+				Description: "Authenticate with access tokens obtained through the OAuth2 client credentials grant. " +
+					"Tokens are cached and refreshed before they expire.",
+				Attributes: map[string]schema.Attribute{
+					"token_url": schema.StringAttribute{
+						Description: "URL of the OAuth2 token endpoint. Required when the block is set.",
+						Optional:    true,
+					},
+					"client_id": schema.StringAttribute{
+						Description: "OAuth2 client identifier. Required when the block is set.",
+						Optional:    true,
+					},
+					"client_secret": schema.StringAttribute{
+						Description: "OAuth2 client secret. Required when the block is set.",
+						Optional:    true,
+						Sensitive:   true,
+					},
+					"scopes": schema.ListAttribute{
+						Description: "Scopes requested for the access token.",
+						ElementType: types.StringType,
+						Optional:    true,
+					},
+					"audience": schema.StringAttribute{
+						Description: "Audience requested for the access token.",
+						Optional:    true,
+					},
+				},
+			},
 		},
 	}
 }
@@ -57,7 +254,11 @@ func (p *taskLiteProvider) Configure(ctx context.Context, req provider.Configure
 		return
 	}
 
-	if config.Host.IsUnknown() {
+	// never log credentials
+	ctx = tflog.MaskAllFieldValuesStrings(ctx, secretValues(config)...)
+	ctx = tflog.MaskMessageStrings(ctx, secretValues(config)...)
+
+	if config.Host.IsUnknown() || config.Hosts.IsUnknown() {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("host"),
 			"Unknown API Host is unknown or empty",
@@ -74,18 +275,16 @@ func (p *taskLiteProvider) Configure(ctx context.Context, req provider.Configure
 	// Default values to environment variables, but override
 	// with Terraform configuration value if set.
 
-	host := os.Getenv("TASKLITE_HOST")
-	if !config.Host.IsNull() {
-		host = config.Host.ValueString()
-	}
+	hosts, diags := apiHosts(ctx, config)
+	resp.Diagnostics.Append(diags...)
 	// If any of the expected configurations are missing, return
 	// errors with provider-specific guidance.
-	if host == "" {
+	if len(hosts) == 0 {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("host"),
 			"Missing TaskLite API Host",
 			"The provider cannot create the TaskLite API client as there is a missing or empty value for the TaskLite API host. "+
-				"Set the host value in the configuration or use the TASKLITE_HOST environment variable. "+
+				"Set the host or hosts value in the configuration or use the TASKLITE_HOST or TASKLITE_HOSTS environment variables. "+
 				"If either is already set, ensure the value is not empty.",
 		)
 	}
@@ -94,18 +293,64 @@ func (p *taskLiteProvider) Configure(ctx context.Context, req provider.Configure
 		return
 	}
 
+	var opts []task.Option
+	for _, configure := range []func(context.Context, hashicupsProviderModel) ([]task.Option, diag.Diagnostics){
+		retryOptions,
+		timeoutOptions,
+		rateLimitOptions,
+		circuitBreakerOptions,
+		endpointOptions,
+		authOptions,
+		tlsOptions,
+		logOptions,
+	} {
+		o, diags := configure(ctx, config)
+		resp.Diagnostics.Append(diags...)
+		opts = append(opts, o...)
+	}
+
+	priority, diags := priorityValidatorFromConfig(config)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	opts = append(opts, p.clientOptions...)
+
 	// Create a new task client using the configuration values
-	client := task.NewClient(host)
+	client := task.NewClient(hosts[0], opts...)
+
+	if !config.SkipHealthCheck.ValueBool() {
+		info, err := client.Probe(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"TaskLite Health Check Failed",
+				fmt.Sprintf("The provider could not reach the TaskLite API at %s: %s\n\n", strings.Join(hosts, ", "), err)+
+					"Check the host and the credentials of the provider configuration. "+
+					"Set skip_health_check to configure the provider without contacting TaskLite.",
+			)
+			return
+		}
+		tflog.Info(ctx, "Detected TaskLite server", map[string]any{"version": info.Version, "capabilities": info.Capabilities})
+	}
 
-	resp.ResourceData = client
+	resp.DataSourceData = client
+	resourceData := &taskResourceData{client: client, priority: priority}
+	if config.Defaults != nil {
+		resourceData.defaults = *config.Defaults
+	}
+	resp.ResourceData = resourceData
 
-	ctx = tflog.SetField(ctx, "Tasklite host", config.Host)
+	ctx = tflog.SetField(ctx, "Tasklite host", strings.Join(hosts, ","))
 	tflog.Debug(ctx, "Configured Tasklite client", map[string]any{"success": true})
 }
 
 // DataSources defines the data sources implemented in the provider.
 func (p *taskLiteProvider) DataSources(_ context.Context) []func() datasource.DataSource {
-	return nil
+	return []func() datasource.DataSource{
+		NewTaskDataSource,
+		NewTasksDataSource,
+	}
 }
 
 // Resources defines the resources implemented in the provider.
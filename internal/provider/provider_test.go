@@ -1,8 +1,38 @@
 package provider
 
 import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/assert"
+
+	"terraform-provider-tasklite/internal/task"
+	"terraform-provider-tasklite/internal/task/fakeserver"
+	"terraform-provider-tasklite/internal/task/tasklitetest"
 )
 
 // testAccProtoV6ProviderFactories is used to instantiate a provider during acceptance testing.
@@ -11,3 +41,601 @@ import (
 var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
 	"tasklite": providerserver.NewProtocol6WithError(New("test")()),
 }
+
+// testAccRecordedProviderFactories returns provider factories whose clients replay the
+// TaskLite responses recorded in the cassette of the test, under testdata/cassettes. Set
+// TASKLITE_RECORD_MODE=record to record the cassette again against a live server.
+func testAccRecordedProviderFactories(t *testing.T) map[string]func() (tfprotov6.ProviderServer, error) {
+	mode, err := task.RecordModeFromEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	recorder, err := task.NewRecorder(filepath.Join("testdata", "cassettes", t.Name()+".json"), mode)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := recorder.Save(); err != nil {
+			t.Error(err)
+		}
+	})
+	return map[string]func() (tfprotov6.ProviderServer, error){
+		"tasklite": providerserver.NewProtocol6WithError(&taskLiteProvider{
+			version:       "test",
+			clientOptions: []task.Option{task.WithRecorder(recorder)},
+		}),
+	}
+}
+
+func TestAccProviderRetries(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// every other request is rejected by the flaky backend
+		if calls.Add(1)%2 == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":1,"title":"Existing task","priority":3,"complete":true}`))
+	}))
+	defer server.Close()
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "tasklite" {
+  host           = "%s"
+  retry_max_wait = "soon"
+}
+
+data "tasklite_task" "test" {
+  id = 1
+}
+`, server.URL),
+				ExpectError: regexp.MustCompile("Invalid Retry Max Wait"),
+			},
+			{
+				Config: fmt.Sprintf(`
+provider "tasklite" {
+  host           = "%s"
+  max_retries    = 1
+  retry_max_wait = "10ms"
+}
+
+data "tasklite_task" "test" {
+  id = 1
+}
+`, server.URL),
+				Check: resource.TestCheckResourceAttr("data.tasklite_task.test", "title", "Existing task"),
+			},
+		},
+	})
+}
+
+func newAuthServer(authorization string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != authorization || r.Header.Get("X-Tenant") != "team-a" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":1,"title":"Existing task","priority":3,"complete":true}`))
+	}))
+}
+
+func TestAccProviderTokenAuth(t *testing.T) {
+	server := newAuthServer("Bearer secret")
+	defer server.Close()
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "tasklite" {
+  host     = "%s"
+  token    = "secret"
+  username = "user"
+  password = "pass"
+}
+
+data "tasklite_task" "test" {
+  id = 1
+}
+`, server.URL),
+				ExpectError: regexp.MustCompile("Conflicting TaskLite Credentials"),
+			},
+			{
+				Config: fmt.Sprintf(`
+provider "tasklite" {
+  host        = "%s"
+  max_retries = 0
+  token       = "wrong"
+  headers = {
+    X-Tenant = "team-a"
+  }
+}
+
+data "tasklite_task" "test" {
+  id = 1
+}
+`, server.URL),
+				// the wrong token is rejected while configuring the provider
+				ExpectError: regexp.MustCompile(`(?s)TaskLite Health Check Failed.*HTTP 401`),
+			},
+			{
+				Config: fmt.Sprintf(`
+provider "tasklite" {
+  host              = "%s"
+  max_retries       = 0
+  token             = "wrong"
+  skip_health_check = true
+  headers = {
+    X-Tenant = "team-a"
+  }
+}
+
+data "tasklite_task" "test" {
+  id = 1
+}
+`, server.URL),
+				ExpectError: regexp.MustCompile("TaskLite Authorization Error"),
+			},
+			{
+				Config: fmt.Sprintf(`
+provider "tasklite" {
+  host  = "%s"
+  token = "secret"
+  headers = {
+    X-Tenant = "team-a"
+  }
+}
+
+data "tasklite_task" "test" {
+  id = 1
+}
+`, server.URL),
+				Check: resource.TestCheckResourceAttr("data.tasklite_task.test", "title", "Existing task"),
+			},
+		},
+	})
+}
+
+func TestAccProviderBasicAuthFromEnvironment(t *testing.T) {
+	server := newAuthServer("Basic dXNlcjpwYXNz")
+	defer server.Close()
+	t.Setenv("TASKLITE_USERNAME", "user")
+	t.Setenv("TASKLITE_PASSWORD", "pass")
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "tasklite" {
+  host = "%s"
+  headers = {
+    X-Tenant = "team-a"
+  }
+}
+
+data "tasklite_task" "test" {
+  id = 1
+}
+`, server.URL),
+				Check: resource.TestCheckResourceAttr("data.tasklite_task.test", "title", "Existing task"),
+			},
+		},
+	})
+}
+
+// newClientCertificate returns a self-signed client certificate and its PEM encoded certificate and key.
+func newClientCertificate(t *testing.T) (*x509.Certificate, string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "terraform"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+	keyDER, _ := x509.MarshalECPrivateKey(key)
+	return cert,
+		string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+}
+
+func TestAccProviderTLS(t *testing.T) {
+	clientCert, clientCertPEM, clientKeyPEM := newClientCertificate(t)
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id":1,"title":"Existing task","priority":3,"complete":true}`))
+	}))
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(clientCert)
+	server.TLS = &tls.Config{ClientCAs: clientCAs, ClientAuth: tls.RequireAndVerifyClientCert}
+	server.StartTLS()
+	defer server.Close()
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(caFile, caPEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The server certificate is not trusted
+			{
+				Config: fmt.Sprintf(`
+provider "tasklite" {
+  host        = "%s"
+  max_retries = 0
+}
+
+data "tasklite_task" "test" {
+  id = 1
+}
+`, server.URL),
+				ExpectError: regexp.MustCompile("certificate"),
+			},
+			{
+				Config: fmt.Sprintf(`
+provider "tasklite" {
+  host            = "%s"
+  ca_cert_file    = "%s"
+  tls_server_name = "example.com"
+  client_cert_pem = <<EOT
+%sEOT
+  client_key_pem  = <<EOT
+%sEOT
+}
+
+data "tasklite_task" "test" {
+  id = 1
+}
+`, server.URL, caFile, clientCertPEM, clientKeyPEM),
+				Check: resource.TestCheckResourceAttr("data.tasklite_task.test", "title", "Existing task"),
+			},
+			{
+				Config: fmt.Sprintf(`
+provider "tasklite" {
+  host                 = "%s"
+  insecure_skip_verify = true
+  client_cert_pem      = <<EOT
+%sEOT
+  client_key_pem       = <<EOT
+%sEOT
+}
+
+data "tasklite_task" "test" {
+  id = 1
+}
+`, server.URL, clientCertPEM, clientKeyPEM),
+				Check: resource.TestCheckResourceAttr("data.tasklite_task.test", "title", "Existing task"),
+			},
+		},
+	})
+}
+
+func TestAccProviderOAuth2(t *testing.T) {
+	var issued atomic.Int32
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if id, secret, ok := r.BasicAuth(); !ok || id != "terraform" || secret != "s3cr3t" || r.FormValue("scope") != "tasks" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		issued.Add(1)
+		_, _ = w.Write([]byte(`{"access_token":"access","token_type":"Bearer","expires_in":3600}`))
+	}))
+	defer tokenServer.Close()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer access" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":1,"title":"Existing task","priority":3,"complete":true}`))
+	}))
+	defer server.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "tasklite" {
+  host  = "%s"
+  token = "static"
+
+  oauth2 {
+    token_url     = "%s"
+    client_id     = "terraform"
+    client_secret = "s3cr3t"
+  }
+}
+
+data "tasklite_task" "test" {
+  id = 1
+}
+`, server.URL, tokenServer.URL),
+				ExpectError: regexp.MustCompile("Conflicting TaskLite Credentials"),
+			},
+			{
+				Config: fmt.Sprintf(`
+provider "tasklite" {
+  host = "%s"
+
+  oauth2 {
+    token_url     = "%s"
+    client_id     = "terraform"
+    client_secret = "s3cr3t"
+    scopes        = ["tasks"]
+  }
+}
+
+data "tasklite_task" "test" {
+  id = 1
+}
+`, server.URL, tokenServer.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.tasklite_task.test", "title", "Existing task"),
+					func(_ *terraform.State) error {
+						if issued.Load() == 0 {
+							return fmt.Errorf("expected an access token to be issued")
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccProviderRequestTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(500 * time.Millisecond)
+		_, _ = w.Write([]byte(`{"id":1,"title":"Existing task","priority":3,"complete":true}`))
+	}))
+	defer server.Close()
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "tasklite" {
+  host            = "%s"
+  request_timeout = "-1s"
+}
+
+data "tasklite_task" "test" {
+  id = 1
+}
+`, server.URL),
+				ExpectError: regexp.MustCompile("Invalid Request Timeout"),
+			},
+			{
+				Config: fmt.Sprintf(`
+provider "tasklite" {
+  host              = "%s"
+  max_retries       = 0
+  request_timeout   = "100ms"
+  skip_health_check = true
+}
+
+data "tasklite_task" "test" {
+  id = 1
+}
+`, server.URL),
+				ExpectError: regexp.MustCompile(`(?s)Read Operation Timed Out.*timed out after`),
+			},
+		},
+	})
+}
+
+func TestAccProviderMaxConcurrentRequests(t *testing.T) {
+	server := tasklitetest.NewServer(t)
+	var inFlight, maxInFlight atomic.Int32
+	server.Inject(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if n := inFlight.Add(1); n > maxInFlight.Load() {
+				maxInFlight.Store(n)
+			}
+			defer inFlight.Add(-1)
+			next.ServeHTTP(w, r)
+		})
+	}, fakeserver.Delay(20*time.Millisecond))
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "tasklite" {
+  host                    = "%s"
+  requests_per_second     = 100
+  max_concurrent_requests = 1
+}
+
+resource "tasklite_task" "test" {
+  count = 5
+  title = "Task ${count.index}"
+}
+`, server.URL),
+				Check: func(_ *terraform.State) error {
+					if n := len(server.Tasks()); n != 5 {
+						return fmt.Errorf("expected 5 tasks to be created, got %d", n)
+					}
+					if n := maxInFlight.Load(); n != 1 {
+						return fmt.Errorf("expected at most 1 request in flight, got %d", n)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestAccProviderCircuitBreaker(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "tasklite" {
+  host                     = "%s"
+  circuit_breaker_cooldown = "never"
+}
+
+data "tasklite_task" "test" {
+  id = 1
+}
+`, server.URL),
+				ExpectError: regexp.MustCompile("Invalid Circuit Breaker Cooldown"),
+			},
+			{
+				PreConfig: func() { calls.Store(0) },
+				Config: fmt.Sprintf(`
+provider "tasklite" {
+  host                      = "%s"
+  max_retries               = 0
+  max_concurrent_requests   = 1
+  circuit_breaker_threshold = 2
+  circuit_breaker_cooldown  = "1h"
+  skip_health_check         = true
+}
+
+data "tasklite_task" "test" {
+  count = 5
+  id    = count.index + 1
+}
+`, server.URL),
+				ExpectError: regexp.MustCompile("TaskLite Unavailable"),
+			},
+			{
+				PreConfig: func() {
+					if n := calls.Load(); n != 2 {
+						t.Errorf("expected 2 requests before the circuit opened, got %d", n)
+					}
+				},
+				Config: fmt.Sprintf(`
+provider "tasklite" {
+  host              = "%s"
+  skip_health_check = true
+}
+`, server.URL),
+			},
+		},
+	})
+}
+
+func TestAccProviderHostsFailover(t *testing.T) {
+	unreachable := httptest.NewServer(http.NotFoundHandler())
+	unreachable.Close()
+	server := tasklitetest.NewServer(t)
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "tasklite" {
+  host  = "%[1]s"
+  hosts = ["%[1]s"]
+}
+
+data "tasklite_task" "test" {
+  id = 1
+}
+`, server.URL),
+				ExpectError: regexp.MustCompile("Invalid Attribute Combination"),
+			},
+			{
+				Config: fmt.Sprintf(`
+provider "tasklite" {
+  hosts       = ["%s", "%s"]
+  max_retries = 0
+}
+
+resource "tasklite_task" "test" {
+  title = "Task on the healthy host"
+}
+`, unreachable.URL, server.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("tasklite_task.test", "id", "1"),
+					resource.TestCheckResourceAttr("tasklite_task.test", "title", "Task on the healthy host"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccProviderHostsFromEnvironment(t *testing.T) {
+	unreachable := httptest.NewServer(http.NotFoundHandler())
+	unreachable.Close()
+	server := tasklitetest.NewServer(t)
+	server.AddTask(task.Task{Title: "Existing task", Priority: 3, Complete: true})
+	t.Setenv("TASKLITE_HOSTS", unreachable.URL+", "+server.URL)
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "tasklite" {
+  host_selection = "round_robin"
+}
+
+data "tasklite_task" "test" {
+  id = 1
+}
+`,
+				Check: resource.TestCheckResourceAttr("data.tasklite_task.test", "title", "Existing task"),
+			},
+		},
+	})
+}
+
+func TestLogOptions(t *testing.T) {
+	server := tasklitetest.NewServer(t)
+	server.AddTask(task.Task{Title: "Existing task", Priority: 3})
+	t.Setenv("TF_LOG_PROVIDER_TASKLITE_HTTP", "TRACE")
+	config := hashicupsProviderModel{
+		RedactedLogFields: types.ListValueMust(types.StringType, []attr.Value{types.StringValue("title")}),
+	}
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	opts, diags := logOptions(ctx, config)
+	assert.False(t, diags.HasError())
+	_, err := task.NewClient(server.URL, opts...).ReadTask(ctx, 1)
+
+	assert.NoError(t, err)
+	assert.Contains(t, output.String(), `\"title\":\"REDACTED\"`)
+	assert.NotContains(t, output.String(), "Existing task")
+}
+
+func TestAuthOptionsRedactHeaders(t *testing.T) {
+	server := tasklitetest.NewServer(t)
+	server.AddTask(task.Task{Title: "Existing task"})
+	config := hashicupsProviderModel{
+		Headers: types.MapValueMust(types.StringType, map[string]attr.Value{"X-Api-Key": types.StringValue("s3cr3t-value")}),
+	}
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	opts, diags := authOptions(ctx, config)
+	assert.False(t, diags.HasError())
+	_, err := task.NewClient(server.URL, opts...).ReadTask(ctx, 1)
+
+	assert.NoError(t, err)
+	assert.Contains(t, output.String(), "X-Api-Key")
+	assert.NotContains(t, output.String(), "s3cr3t-value")
+}
@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-tasklite/internal/task"
+)
+
+var (
+	_ datasource.DataSource              = &taskDataSource{}
+	_ datasource.DataSourceWithConfigure = &taskDataSource{}
+)
+
+func NewTaskDataSource() datasource.DataSource {
+	return &taskDataSource{}
+}
+
+type taskDataSource struct {
+	client task.ClientInterface
+}
+
+// Metadata returns the data source type name.
+func (d *taskDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_task"
+}
+
+// Schema defines the schema for the data source.
+func (d *taskDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Looks up a single existing task by its numeric identifier.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int32Attribute{
+				Description: "Numeric identifier of the task to look up.",
+				Required:    true,
+			},
+			"title": schema.StringAttribute{
+				Description: "Title of the task, as stored in TaskLite: it includes the provider `defaults.title_prefix` of tasks managed by `tasklite_task`.",
+				Computed:    true,
+			},
+			"priority": schema.Int32Attribute{
+				Description: "Priority of the task.",
+				Computed:    true,
+			},
+			"complete": schema.BoolAttribute{
+				Description: "Whether the task is complete.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *taskDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*task.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *tasklite.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *taskDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config taskModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Reading task", map[string]interface{}{
+		"ID": config.ID.ValueInt32(),
+	})
+
+	t, err := d.client.ReadTask(ctx, config.ID.ValueInt32())
+
+	if errors.Is(err, task.ErrNotFound) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("id"),
+			"Task Not Found",
+			fmt.Sprintf("No task with ID %d exists in TaskLite. Ensure the ID is correct and the task has not been deleted.", config.ID.ValueInt32()),
+		)
+		return
+	}
+
+	if err != nil {
+		addErrorDiagnostic(&resp.Diagnostics, "Read", err)
+		tflog.Error(ctx, "Failed to Read the task", map[string]interface{}{"error": err})
+		return
+	}
+
+	state := mapTaskToTaskModel(t)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,67 @@
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func newDataSourceServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Fatal("Unexpected " + r.Method + " " + r.RequestURI)
+		}
+		switch r.URL.Path {
+		case "/api/task/1/":
+			_, _ = w.Write([]byte(`{"id":1,"title":"Existing task","priority":3,"complete":true}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestAccTaskDataSource(t *testing.T) {
+	server := newDataSourceServer(t)
+	defer server.Close()
+	dataSourceName := "data.tasklite_task.test"
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read existing task
+			{
+				Config: fmt.Sprintf(`
+provider "tasklite" {
+  host = "%s"
+}
+
+data "tasklite_task" "test" {
+  id = 1
+}
+`, server.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "id", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "title", "Existing task"),
+					resource.TestCheckResourceAttr(dataSourceName, "priority", "3"),
+					resource.TestCheckResourceAttr(dataSourceName, "complete", "true"),
+				),
+			},
+			// Read missing task
+			{
+				Config: fmt.Sprintf(`
+provider "tasklite" {
+  host = "%s"
+}
+
+data "tasklite_task" "test" {
+  id = 2
+}
+`, server.URL),
+				ExpectError: regexp.MustCompile("Task Not Found"),
+			},
+		},
+	})
+}
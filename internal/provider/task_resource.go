@@ -2,28 +2,69 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
-
+	"net/http"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int32default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int32planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"terraform-provider-tasklite/internal/task"
 )
 
 var (
-	_ resource.Resource              = &taskResource{}
-	_ resource.ResourceWithConfigure = &taskResource{}
+	_ resource.Resource                = &taskResource{}
+	_ resource.ResourceWithConfigure   = &taskResource{}
+	_ resource.ResourceWithImportState = &taskResource{}
+	_ resource.ResourceWithModifyPlan  = &taskResource{}
+)
+
+// Default operation timeouts, overridable with the timeouts block.
+const (
+	defaultCreateTimeout = 20 * time.Minute
+	defaultReadTimeout   = 5 * time.Minute
+	defaultUpdateTimeout = 20 * time.Minute
+	defaultDeleteTimeout = 20 * time.Minute
 )
 
+// privateStateETag holds the ETag of the task as last read from TaskLite, sent in If-Match
+// so that updates and deletes do not overwrite changes made outside Terraform.
+const privateStateETag = "etag"
+
+// privateState is implemented by the private state data of resource requests and responses.
+type privateState interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
 func NewTaskResource() resource.Resource {
 	return &taskResource{}
 }
 
 type taskResource struct {
 	client task.ClientInterface
+	// priority validates the planned priorities against the range configured by the provider.
+	priority priorityValidator
+	// defaults are planned for the attributes omitted from the configuration.
+	defaults taskDefaultsModel
+	// configured reports whether the provider data was received, so that the defaults and
+	// the priority range are applied.
+	configured bool
 }
 
 // Metadata returns the resource type name.
@@ -32,16 +73,32 @@ func (r *taskResource) Metadata(_ context.Context, req resource.MetadataRequest,
 }
 
 // Schema defines the schema for the resource.
-func (r *taskResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *taskResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"title": schema.StringAttribute{
 				Description: "Title of the title",
 				Required:    true,
+				Validators: []validator.String{
+					titleValidator{maxLength: maxTitleLength},
+				},
+			},
+			"full_title": schema.StringAttribute{
+				Description: "Title of the task in TaskLite: `title` with the provider `defaults.title_prefix`.",
+				Computed:    true,
 			},
 			"id": schema.Int32Attribute{
 				Description: "Numeric identifier of the task., will be auto-generate by task api",
 				Computed:    true,
+				// the provider defaults are planned after computed attributes are marked
+				// unknown, which would otherwise plan a new ID for tasks they leave unchanged
+				PlanModifiers: []planmodifier.Int32{
+					int32planmodifier.UseStateForUnknown(),
+				},
+			},
+			"pending_idempotency_key": schema.StringAttribute{
+				Description: "Idempotency key of a create TaskLite has not confirmed, with which the next apply or destroy resumes it. Null once the task is created.",
+				Computed:    true,
 			},
 			"priority": schema.Int32Attribute{
 				Description: "Priority of the task. Default is 0",
@@ -56,6 +113,14 @@ func (r *taskResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				Default:     booldefault.StaticBool(false),
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
@@ -64,24 +129,29 @@ func (r *taskResource) Configure(_ context.Context, req resource.ConfigureReques
 		return
 	}
 
-	client, ok := req.ProviderData.(*task.Client)
+	data, ok := req.ProviderData.(*taskResourceData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
-			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *tasklite.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *taskResourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
-
+	r.client = data.client
+	r.priority = data.priority
+	r.defaults = data.defaults
+	r.configured = true
 }
 
 // Create creates the resource and sets the initial Terraform state.
 func (r *taskResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var plan taskModel
+	ctx, span := startSpan(ctx, "create")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
+	var plan taskResourceModel
 	// Read Terraform data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 
@@ -89,17 +159,22 @@ func (r *taskResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
-	tflog.Debug(ctx, "Creating task", map[string]any{"task": plan})
-	t, err := r.client.CreateTask(ctx, mapTaskModelToTask(plan))
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
 
+	key, err := task.NewIdempotencyKey()
 	if err != nil {
 		logErrorAndAddDiagnostic(ctx, req, resp, err)
 		return
 	}
 
-	tflog.Debug(ctx, "Task created", map[string]any{"task": t})
-	s := mapTaskToTaskModel(t)
-	resp.Diagnostics.Append(resp.State.Set(ctx, &s)...)
+	tflog.Debug(ctx, "Creating task", map[string]any{"task": plan, "idempotency_key": key})
+	r.createTask(ctx, "Create", plan, key, &resp.State, resp.Private, &resp.Diagnostics)
 
 	if resp.Diagnostics.HasError() {
 		tflog.Error(ctx, "An error return while saving state")
@@ -107,14 +182,137 @@ func (r *taskResource) Create(ctx context.Context, req resource.CreateRequest, r
 	}
 }
 
+// createTask sends the create request for plan with key as its Idempotency-Key and saves
+// the created task to state. When the server has not confirmed the request, e.g. the
+// connection dropped after it was sent, the create fails and the planned task is saved
+// without an ID, with key as its pending_idempotency_key, so the next apply or destroy
+// resumes the same request instead of creating a duplicate task. The key is kept in the
+// state rather than the private state, which Terraform drops when a create fails.
+func (r *taskResource) createTask(ctx context.Context, operation string, plan taskResourceModel, key string, state *tfsdk.State, private privateState, diags *diag.Diagnostics) {
+	t, err := r.client.CreateTask(task.ContextWithIdempotencyKey(ctx, key), mapTaskModelToTask(plan.apiTaskModel()))
+
+	if support := r.client.Support(task.CapabilityIdempotencyKeys); err != nil && isUnconfirmedCreate(err) && support != task.Supported {
+		diags.AddError(
+			"Task Creation Unconfirmed",
+			fmt.Sprintf("TaskLite did not confirm the creation of the task, got error: %s\n\n", err)+
+				fmt.Sprintf("%s, so the request cannot be resumed without risking a duplicate task. ", idempotencyKeysError(support))+
+				"Check whether the task was created and import it, or apply again.",
+		)
+		tflog.Error(ctx, "Task creation unconfirmed", map[string]any{"error": err})
+		return
+	}
+
+	if err != nil && isUnconfirmedCreate(err) {
+		tflog.Warn(ctx, "Task creation unconfirmed, keeping the idempotency key", map[string]any{"idempotency_key": key, "error": err})
+		plan.ID = types.Int32Null()
+		plan.PendingIdempotencyKey = types.StringValue(key)
+		diags.Append(state.Set(ctx, &plan)...)
+		diags.AddError(
+			"Task Creation Unconfirmed",
+			fmt.Sprintf("TaskLite did not confirm the creation of the task, got error: %s\n\n", err)+
+				"The task may or may not have been created, so it is saved without an ID. The next apply or destroy resumes "+
+				"the request with the same idempotency key, so no duplicate task is created.",
+		)
+		return
+	}
+
+	if err != nil {
+		addErrorDiagnostic(diags, operation, err)
+		tflog.Error(ctx, fmt.Sprintf("Failed to %s the task", operation), map[string]interface{}{"error": err})
+		return
+	}
+
+	tflog.Debug(ctx, "Task created", map[string]any{"task": t})
+	diags.Append(setETag(ctx, private, t.ETag)...)
+	plan.setTask(t, r.defaults.TitlePrefix.ValueString())
+	plan.PendingIdempotencyKey = types.StringNull()
+	setSpanTaskID(ctx, plan.ID)
+	diags.Append(state.Set(ctx, &plan)...)
+}
+
+// setETag stores etag in the private state, or removes it when the server sent none.
+func setETag(ctx context.Context, private privateState, etag string) diag.Diagnostics {
+	if etag == "" {
+		return private.SetKey(ctx, privateStateETag, nil)
+	}
+	value, _ := json.Marshal(etag)
+	return private.SetKey(ctx, privateStateETag, value)
+}
+
+// contextWithETag returns a context sending the ETag stored in the private state in If-Match.
+func contextWithETag(ctx context.Context, private privateState) (context.Context, diag.Diagnostics) {
+	value, diags := private.GetKey(ctx, privateStateETag)
+	var etag string
+	if value != nil {
+		if err := json.Unmarshal(value, &etag); err != nil {
+			diags.AddWarning("Invalid Private State", fmt.Sprintf("Ignoring the task ETag stored in the private state: %s", err))
+		}
+	}
+	return task.ContextWithIfMatch(ctx, etag), diags
+}
+
+// resumeCreate sends the unconfirmed create of the task again, with its original
+// idempotency key, and returns the task created by either request.
+func (r *taskResource) resumeCreate(ctx context.Context, state taskResourceModel) (*task.Task, error) {
+	key := state.PendingIdempotencyKey.ValueString()
+	if key == "" {
+		return nil, errors.New("no idempotency key stored for the task")
+	}
+	if support := r.client.Support(task.CapabilityIdempotencyKeys); support != task.Supported {
+		return nil, idempotencyKeysError(support)
+	}
+	tflog.Debug(ctx, "Resuming task creation", map[string]any{"task": state, "idempotency_key": key})
+	return r.client.CreateTask(task.ContextWithIdempotencyKey(ctx, key), mapTaskModelToTask(state.apiTaskModel()))
+}
+
+// idempotencyKeysError explains why the create of a task cannot be resumed safely.
+func idempotencyKeysError(support task.Support) error {
+	if support == task.SupportUnknown {
+		return errors.New("the TaskLite server does not advertise support for idempotency keys: it predates version discovery, or was not probed because of skip_health_check")
+	}
+	return errors.New("the TaskLite server does not support idempotency keys")
+}
+
+// isUnconfirmedCreate reports whether a failed create may still have been applied by the
+// server, i.e. it failed in transit or the server answered with a 5xx status.
+func isUnconfirmedCreate(err error) bool {
+	if errors.Is(err, task.ErrCircuitOpen) {
+		// the request was never sent
+		return false
+	}
+	var apiErr *task.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode >= 500
+	}
+	return true
+}
+
 // Read refreshes the Terraform state with the latest data.
 func (r *taskResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var state taskModel
+	ctx, span := startSpan(ctx, "read")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
+	var state taskResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	// the create has not been confirmed yet, there is nothing to refresh until it is resumed
+	if state.ID.IsNull() {
+		tflog.Debug(ctx, "Task creation unconfirmed, skipping refresh")
+		return
+	}
+
+	setSpanTaskID(ctx, state.ID)
 	tflog.Debug(ctx, "Refreshing task with the server data", map[string]interface{}{
 		"ID": state.ID.ValueInt32(),
 	})
@@ -122,35 +320,75 @@ func (r *taskResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	// get refreshed task from the api
 	t, err := r.client.ReadTask(ctx, state.ID.ValueInt32())
 
+	// the task was deleted outside of Terraform, remove it from the state so it is re-created
+	if errors.Is(err, task.ErrNotFound) {
+		tflog.Warn(ctx, "Task not found, removing it from the state", map[string]interface{}{
+			"ID": state.ID.ValueInt32(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
 	if err != nil {
 		logErrorAndAddDiagnostic(ctx, req, resp, err)
 		return
 	}
 
-	state = mapTaskToTaskModel(t)
+	state.setTask(t, r.defaults.TitlePrefix.ValueString())
+	resp.Diagnostics.Append(setETag(ctx, resp.Private, t.ETag)...)
 
 	// set refreshed state
-	resp.Diagnostics.Append(req.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 }
 
 func (r *taskResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var state taskModel
+	ctx, span := startSpan(ctx, "update")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
+	var state taskResourceModel
 	// read Terraform state
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	var plan taskModel
+	var plan taskResourceModel
 	// read Terraform plan into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	setSpanTaskID(ctx, state.ID)
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	if state.ID.IsNull() {
+		// resume the unconfirmed create with its original idempotency key
+		key := state.PendingIdempotencyKey.ValueString()
+		if key == "" {
+			logErrorAndAddDiagnostic(ctx, req, resp, errors.New("task ID missing from the terraform state"))
+			return
+		}
+		if support := r.client.Support(task.CapabilityIdempotencyKeys); support != task.Supported {
+			logErrorAndAddDiagnostic(ctx, req, resp, fmt.Errorf("cannot resume the unconfirmed task creation: %w", idempotencyKeysError(support)))
+			return
+		}
+
+		tflog.Debug(ctx, "Resuming task creation", map[string]any{"task": plan, "idempotency_key": key})
+		r.createTask(ctx, "Update", plan, key, &resp.State, resp.Private, &resp.Diagnostics)
+		return
+	}
+
 	if state.ID.ValueInt32() == 0 {
 		logErrorAndAddDiagnostic(ctx, req, resp, fmt.Errorf("unexpected task ID %d found in the terraform state", state.ID.ValueInt32()))
 		return
@@ -159,14 +397,19 @@ func (r *taskResource) Update(ctx context.Context, req resource.UpdateRequest, r
 
 	tflog.Debug(ctx, "Updating task", map[string]any{"task": plan})
 
-	t, err := r.client.UpdateTask(ctx, mapTaskModelToTask(plan))
+	ctx, diags = contextWithETag(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	t, err := r.updateTask(ctx, plan.apiTaskModel(), state.apiTaskModel())
 
 	if err != nil {
 		logErrorAndAddDiagnostic(ctx, req, resp, err)
 		return
 	}
 
-	plan = mapTaskToTaskModel(t)
+	if t != nil {
+		plan.setTask(t, r.defaults.TitlePrefix.ValueString())
+		resp.Diagnostics.Append(setETag(ctx, resp.Private, t.ETag)...)
+	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
 		tflog.Error(ctx, "An error return while saving state")
@@ -174,49 +417,224 @@ func (r *taskResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	}
 }
 
+// updateTask sends the attributes of plan that differ from state as a PATCH, so that
+// attributes changed by other tools are left alone. The whole task is sent with PUT when
+// the server does not support PATCH. It returns nil when no task attribute changed.
+func (r *taskResource) updateTask(ctx context.Context, plan, state taskModel) (*task.Task, error) {
+	// servers that may support PATCH are sent one, falling back to PUT when it is rejected
+	if r.client.Support(task.CapabilityPatch) == task.Unsupported {
+		return r.client.UpdateTask(ctx, mapTaskModelToTask(plan))
+	}
+
+	fields := changedFields(plan, state)
+	if len(fields) == 0 {
+		tflog.Debug(ctx, "No task attribute changed, skipping the update request")
+		return nil, nil
+	}
+
+	t, err := r.client.PatchTask(ctx, plan.ID.ValueInt32(), fields)
+
+	var apiErr *task.APIError
+	if errors.As(err, &apiErr) && (apiErr.StatusCode == http.StatusMethodNotAllowed || apiErr.StatusCode == http.StatusNotImplemented) {
+		tflog.Info(ctx, "TaskLite does not support PATCH, replacing the whole task", map[string]any{"status": apiErr.StatusCode})
+		return r.client.UpdateTask(ctx, mapTaskModelToTask(plan))
+	}
+
+	return t, err
+}
+
+// changedFields returns the JSON Merge Patch fields of the attributes of plan that differ
+// from state.
+func changedFields(plan, state taskModel) map[string]any {
+	fields := map[string]any{}
+	if !plan.Title.Equal(state.Title) {
+		fields["title"] = plan.Title.ValueString()
+	}
+	if !plan.Priority.Equal(state.Priority) {
+		fields["priority"] = plan.Priority.ValueInt32()
+	}
+	if !plan.Complete.Equal(state.Complete) {
+		fields["complete"] = plan.Complete.ValueBool()
+	}
+	return fields
+}
+
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *taskResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var state taskModel
+	ctx, span := startSpan(ctx, "delete")
+	defer func() { endSpan(span, resp.Diagnostics) }()
+
+	var state taskResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	if state.ID.IsNull() {
+		// resume the unconfirmed create to learn the ID of the task, creating it if it never was
+		t, err := r.resumeCreate(ctx, state)
+		if err != nil {
+			tflog.Warn(ctx, "Failed to resume the unconfirmed task creation", map[string]any{"error": err})
+			resp.Diagnostics.AddWarning(
+				"Task Creation Unconfirmed",
+				fmt.Sprintf("TaskLite never confirmed the creation of this task, and resuming it failed, got error: %s\n\n", err)+
+					"The task cannot be deleted by ID. If the task was created, delete it manually.",
+			)
+			return
+		}
+		state.ID = types.Int32Value(t.ID)
+		ctx = task.ContextWithIfMatch(ctx, t.ETag)
+	} else {
+		ctx, diags = contextWithETag(ctx, req.Private)
+		resp.Diagnostics.Append(diags...)
+	}
+
+	setSpanTaskID(ctx, state.ID)
 	tflog.Debug(ctx, "Deleting task", map[string]interface{}{
 		"ID": state.ID.ValueInt32(),
 	})
 
 	err := r.client.DeleteTask(ctx, state.ID.ValueInt32())
 
+	// the task is already gone, which is the desired outcome
+	if errors.Is(err, task.ErrNotFound) {
+		tflog.Warn(ctx, "Task already deleted", map[string]interface{}{
+			"ID": state.ID.ValueInt32(),
+		})
+		return
+	}
+
 	if err != nil {
 		logErrorAndAddDiagnostic(ctx, req, resp, err)
 		return
 	}
 }
 
+// ModifyPlan plans the defaults of the provider, validates the planned priority against the
+// range configured by the provider, and plans an update for tasks whose creation was not
+// confirmed, so the create is resumed.
+func (r *taskResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	// the defaults and the priority range are configured by the provider, so they cannot be
+	// set in the schema, whose validators run before the provider is configured
+	if r.configured {
+		r.planDefaults(ctx, req, resp)
+		// TaskLite receives the title with the prefix, which counts towards its length
+		var fullTitle types.String
+		resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("full_title"), &fullTitle)...)
+		if n := utf8.RuneCountInString(fullTitle.ValueString()); n > maxTitleLength {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("title"),
+				"Invalid Task Title",
+				fmt.Sprintf("The title, prefixed with the provider defaults.title_prefix, must be at most %d characters long, got: %d characters.", maxTitleLength, n),
+			)
+		}
+		var priority types.Int32
+		resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("priority"), &priority)...)
+		validation := validator.Int32Response{}
+		r.priority.ValidateInt32(ctx, validator.Int32Request{Path: path.Root("priority"), ConfigValue: priority}, &validation)
+		resp.Diagnostics.Append(validation.Diagnostics...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	// the key of an unconfirmed create is cleared when it is resumed, and is otherwise null
+	var id types.Int32
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("id"), &id)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !id.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("pending_idempotency_key"), types.StringNull())...)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("id"), types.Int32Unknown())...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("pending_idempotency_key"), types.StringUnknown())...)
+}
+
+// ImportState imports an existing task into Terraform state by its numeric ID.
+func (r *taskResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id, err := strconv.ParseInt(req.ID, 10, 32)
+	if err != nil || id <= 0 {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected a positive numeric task ID, got: %q", req.ID),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, "Importing task", map[string]interface{}{
+		"ID": id,
+	})
+
+	t, err := r.client.ReadTask(ctx, int32(id))
+
+	if errors.Is(err, task.ErrNotFound) {
+		resp.Diagnostics.AddError(
+			"Cannot Import Non-Existent Task",
+			fmt.Sprintf("No task with ID %d exists in TaskLite.", id),
+		)
+		return
+	}
+
+	if err != nil {
+		addErrorDiagnostic(&resp.Diagnostics, "Import", err)
+		tflog.Error(ctx, "Failed to Import the task", map[string]interface{}{"error": err})
+		return
+	}
+
+	resp.Diagnostics.Append(setETag(ctx, resp.Private, t.ETag)...)
+
+	// set the task attributes only, leaving the timeouts block null
+	var state taskResourceModel
+	state.setTask(t, r.defaults.TitlePrefix.ValueString())
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), state.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("title"), state.Title)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("full_title"), state.FullTitle)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("priority"), state.Priority)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("complete"), state.Complete)...)
+}
+
 func logErrorAndAddDiagnostic(ctx context.Context, req any, resp any, err error) {
 	operation := ""
 	switch req.(type) {
 	case resource.CreateRequest:
 		operation = "Create"
 		if respPtr, ok := resp.(*resource.CreateResponse); ok {
-			respPtr.Diagnostics.AddError(fmt.Sprintf("%s Operation Error", operation), fmt.Sprintf("Failed to %s the task, got error: %s", operation, err))
+			addErrorDiagnostic(&respPtr.Diagnostics, operation, err)
 		}
 	case resource.ReadRequest:
 		operation = "Read"
 		if respPtr, ok := resp.(*resource.ReadResponse); ok {
-			respPtr.Diagnostics.AddError(fmt.Sprintf("%s Operation Error", operation), fmt.Sprintf("Failed to %s the task, got error: %s", operation, err))
+			addErrorDiagnostic(&respPtr.Diagnostics, operation, err)
 		}
 	case resource.UpdateRequest:
 		operation = "Update"
 		if respPtr, ok := resp.(*resource.UpdateResponse); ok {
-			respPtr.Diagnostics.AddError(fmt.Sprintf("%s Operation Error", operation), fmt.Sprintf("Failed to %s the task, got error: %s", operation, err))
+			addErrorDiagnostic(&respPtr.Diagnostics, operation, err)
 		}
 	case resource.DeleteRequest:
 		operation = "Delete"
 		if respPtr, ok := resp.(*resource.DeleteResponse); ok {
-			respPtr.Diagnostics.AddError(fmt.Sprintf("%s Operation Error", operation), fmt.Sprintf("Failed to %s the task, got error: %s", operation, err))
+			addErrorDiagnostic(&respPtr.Diagnostics, operation, err)
 		}
 	}
 	tflog.Error(ctx, fmt.Sprintf("Failed to %s the task", operation), map[string]interface{}{"error": err})
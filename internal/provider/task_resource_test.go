@@ -1,15 +1,22 @@
 package provider
 
 import (
-	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
-	"net/http/httptest"
+	"regexp"
 	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/assert"
+
+	"terraform-provider-tasklite/internal/task"
+	"terraform-provider-tasklite/internal/task/fakeserver"
+	"terraform-provider-tasklite/internal/task/tasklitetest"
 )
 
 const (
@@ -20,51 +27,15 @@ provider "tasklite" {
 `
 )
 
-func newResourceServer(t *testing.T) *httptest.Server {
-	var data atomic.Value
-	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		switch {
-		case r.Method == http.MethodPost:
-			body, _ := io.ReadAll(r.Body)
-			parsedBody := make(map[string]interface{})
-			_ = json.Unmarshal(body, &parsedBody)
-			parsedBody["id"] = 1
-			body, _ = json.Marshal(parsedBody)
-			data.Store(body)
-			w.WriteHeader(http.StatusCreated)
-			_, _ = w.Write(body)
-		case r.Method == http.MethodGet:
-			if storedData, ok := data.Load().([]byte); ok {
-				_, _ = w.Write(storedData)
-			} else {
-				t.Fatal("Failed to assert type []byte for data.Load()")
-			}
-		case r.Method == http.MethodPut:
-			body, _ := io.ReadAll(r.Body)
-			parsedBody := make(map[string]interface{})
-			_ = json.Unmarshal(body, &parsedBody)
-			parsedBody["id"] = 1
-			body, _ = json.Marshal(parsedBody)
-			data.Store(body)
-			w.WriteHeader(http.StatusOK)
-			_, _ = w.Write(body)
-		case r.Method == http.MethodDelete:
-			w.WriteHeader(http.StatusNoContent)
-			data.Store([]byte(nil))
-		default:
-			t.Fatal("Unexpected " + r.Method + " " + r.RequestURI)
-		}
-	}))
-}
-
+// TestAccTaskResource replays the responses of a TaskLite server recorded in its cassette.
+// Record them again with a server on 127.0.0.1:3000, e.g. `go run ./cmd/tasklite-server`,
+// and TASKLITE_RECORD_MODE=record.
 func TestAccTaskResource(t *testing.T) {
-	server := newResourceServer(t)
-	defer server.Close()
 	resourceName := "tasklite_task.test"
 	title := "Task created by terraform"
 	updatedTitle := "Updated Task by terraform"
 	resource.Test(t, resource.TestCase{
-		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		ProtoV6ProviderFactories: testAccRecordedProviderFactories(t),
 		Steps: []resource.TestStep{
 			// Create resource
 			{
@@ -111,3 +82,454 @@ resource "tasklite_task" "test" {
 		},
 	})
 }
+
+func TestAccTaskResourceDeletedOutsideTerraform(t *testing.T) {
+	server := tasklitetest.NewServer(t)
+	config := fmt.Sprintf(`
+provider "tasklite" {
+  host = "%s"
+}
+
+resource "tasklite_task" "test" {
+   title = "Task deleted in the UI"
+}
+`, server.URL)
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+			},
+			// Delete the task behind Terraform's back, a re-create should be planned
+			{
+				PreConfig: func() {
+					if !server.RemoveTask(1) {
+						t.Fatal("task 1 not found")
+					}
+				},
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccTaskResourceImport(t *testing.T) {
+	server := tasklitetest.NewServer(t)
+	resourceName := "tasklite_task.test"
+	config := fmt.Sprintf(`
+provider "tasklite" {
+  host = "%s"
+}
+
+resource "tasklite_task" "test" {
+   title = "Imported task"
+   priority = 2
+}
+`, server.URL)
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+			},
+			// Import by ID
+			{
+				Config:            config,
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     "1",
+				ImportStateVerify: true,
+			},
+			// Import with a non numeric ID
+			{
+				Config:        config,
+				ResourceName:  resourceName,
+				ImportState:   true,
+				ImportStateId: "one",
+				ExpectError:   regexp.MustCompile("Invalid Import ID"),
+			},
+		},
+	})
+}
+
+func TestAccTaskResourceImportBlock(t *testing.T) {
+	server := tasklitetest.NewServer(t)
+	// Create the task outside of Terraform so it can be adopted.
+	server.AddTask(task.Task{Title: "Legacy task", Priority: 4})
+	resourceName := "tasklite_task.test"
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "tasklite" {
+  host = "%s"
+}
+
+import {
+  to = tasklite_task.test
+  id = "1"
+}
+
+resource "tasklite_task" "test" {
+   title = "Legacy task"
+   priority = 4
+}
+`, server.URL),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionNoop),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", "1"),
+					resource.TestCheckResourceAttr(resourceName, "title", "Legacy task"),
+					resource.TestCheckResourceAttr(resourceName, "priority", "4"),
+					resource.TestCheckResourceAttr(resourceName, "complete", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccTaskResourceCreateRetry(t *testing.T) {
+	server := tasklitetest.NewServer(t)
+	server.Inject(fakeserver.OnMethod(http.MethodPost, fakeserver.Times(1, fakeserver.LoseResponse())))
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "tasklite" {
+  host           = "%s"
+  retry_max_wait = "10ms"
+}
+
+resource "tasklite_task" "test" {
+   title = "Task created on retry"
+}
+`, server.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("tasklite_task.test", "id", "1"),
+					func(_ *terraform.State) error {
+						if n := len(server.Tasks()); n != 1 {
+							return fmt.Errorf("expected 1 task to be created, got %d", n)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccTaskResourceCreateUnconfirmed(t *testing.T) {
+	server := tasklitetest.NewServer(t)
+	// the create request must open a new connection: net/http transparently resends
+	// requests with an Idempotency-Key when a reused connection is closed
+	server.Config.SetKeepAlivesEnabled(false)
+	server.Inject(fakeserver.OnMethod(http.MethodPost, fakeserver.Times(1, fakeserver.LoseResponse())))
+	resourceName := "tasklite_task.test"
+	config := fmt.Sprintf(`
+provider "tasklite" {
+  host        = "%s"
+  max_retries = 0
+}
+
+resource "tasklite_task" "test" {
+   title = "Task with a lost response"
+}
+`, server.URL)
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The response is lost, the apply fails and the task is saved without an ID
+			{
+				Config:      config,
+				ExpectError: regexp.MustCompile("Task Creation Unconfirmed"),
+			},
+			// Terraform replaces the tainted task: the destroy resumes the create to delete
+			// the task created by the lost request, then a new one is created
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", "2"),
+					resource.TestCheckNoResourceAttr(resourceName, "pending_idempotency_key"),
+					func(_ *terraform.State) error {
+						if n := len(server.Tasks()); n != 1 {
+							return fmt.Errorf("expected 1 task to be created, got %d", n)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccTaskResourceDeleteUnconfirmed(t *testing.T) {
+	server := tasklitetest.NewServer(t)
+	server.Config.SetKeepAlivesEnabled(false)
+	server.Inject(fakeserver.OnMethod(http.MethodPost, fakeserver.Times(1, fakeserver.LoseResponse())))
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		// the destroy resumes the create to learn the ID of the task, then deletes it
+		CheckDestroy: func(_ *terraform.State) error {
+			if tasks := server.Tasks(); len(tasks) != 0 {
+				return fmt.Errorf("expected the task to be deleted, got %+v", tasks)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "tasklite" {
+  host        = "%s"
+  max_retries = 0
+}
+
+resource "tasklite_task" "test" {
+  title = "Task with a lost response"
+}
+`, server.URL),
+				ExpectError: regexp.MustCompile("Task Creation Unconfirmed"),
+			},
+		},
+	})
+
+	// the create is resumed with its original idempotency key, so no duplicate is created
+	var requests []fakeserver.Request
+	for _, r := range server.Requests() {
+		if r.Path != task.VERSION_URI {
+			requests = append(requests, r)
+		}
+	}
+	if assert.Len(t, requests, 3) {
+		assert.Equal(t, http.MethodPost, requests[1].Method)
+		assert.Equal(t, requests[0].Header.Get(task.IdempotencyKeyHeader), requests[1].Header.Get(task.IdempotencyKeyHeader))
+		assert.Equal(t, http.MethodDelete, requests[2].Method)
+	}
+}
+
+func TestAccTaskResourceTimeouts(t *testing.T) {
+	server := tasklitetest.NewServer(t)
+	config := func(title string) string {
+		return fmt.Sprintf(`
+provider "tasklite" {
+  host        = "%s"
+  max_retries = 0
+}
+
+resource "tasklite_task" "test" {
+  title = "%s"
+
+  timeouts {
+    update = "100ms"
+  }
+}
+`, server.URL, title)
+	}
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("Fast task"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("tasklite_task.test", "title", "Fast task"),
+					resource.TestCheckResourceAttr("tasklite_task.test", "timeouts.update", "100ms"),
+				),
+			},
+			{
+				PreConfig: func() {
+					server.Inject(fakeserver.OnMethod(http.MethodPatch, fakeserver.Delay(500*time.Millisecond)))
+				},
+				Config:      config("Slow task"),
+				ExpectError: regexp.MustCompile(`(?s)Update Operation Timed Out.*timed out after`),
+			},
+		},
+	})
+}
+
+func TestAccTaskResourceCreateUnconfirmedWithoutIdempotencyKeys(t *testing.T) {
+	server := tasklitetest.NewServer(t)
+	// the server does not advertise idempotency keys
+	server.SetServerInfo(&task.ServerInfo{Version: "1.0.0"})
+	server.Inject(fakeserver.OnMethod(http.MethodPost, fakeserver.Times(1, fakeserver.LoseResponse())))
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "tasklite" {
+  host = "%s"
+}
+
+resource "tasklite_task" "test" {
+   title = "Task with a lost response"
+}
+`, server.URL),
+				ExpectError: regexp.MustCompile("does not support idempotency keys"),
+			},
+		},
+	})
+
+	// neither the client nor net/http resent the create
+	assert.Len(t, server.Tasks(), 1)
+}
+
+func TestAccTaskResourceCreateUnconfirmedUnprobed(t *testing.T) {
+	server := tasklitetest.NewServer(t)
+	server.Config.SetKeepAlivesEnabled(false)
+	server.Inject(fakeserver.OnMethod(http.MethodPost, fakeserver.Times(1, fakeserver.LoseResponse())))
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// without the health check, the provider cannot know that TaskLite honours idempotency keys
+			{
+				Config: fmt.Sprintf(`
+provider "tasklite" {
+  host              = "%s"
+  max_retries       = 0
+  skip_health_check = true
+}
+
+resource "tasklite_task" "test" {
+   title = "Task with a lost response"
+}
+`, server.URL),
+				ExpectError: regexp.MustCompile("does not advertise support for idempotency keys"),
+			},
+		},
+	})
+}
+
+func TestAccTaskResourceChangedOutsideTerraform(t *testing.T) {
+	server := tasklitetest.NewServer(t)
+	// while concurrentEdits is set, the task is changed by someone else right after every read
+	var concurrentEdits atomic.Bool
+	server.Inject(fakeserver.OnMethod(http.MethodGet, func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r)
+			if concurrentEdits.Load() {
+				server.EditTask(1, func(*task.Task) {})
+			}
+		})
+	}))
+	config := func(title string) string {
+		return fmt.Sprintf(`
+provider "tasklite" {
+  host = "%s"
+}
+
+resource "tasklite_task" "test" {
+  title = "%s"
+}
+`, server.URL, title)
+	}
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("Shared task"),
+				Check:  resource.TestCheckResourceAttr("tasklite_task.test", "id", "1"),
+			},
+			// the task changes between the refresh and the update, which must not overwrite it
+			{
+				PreConfig:   func() { concurrentEdits.Store(true) },
+				Config:      config("Renamed task"),
+				ExpectError: regexp.MustCompile(`(?s)Task Changed Outside Terraform.*HTTP 412`),
+			},
+			{
+				PreConfig: func() { concurrentEdits.Store(false) },
+				Config:    config("Renamed task"),
+				Check:     resource.TestCheckResourceAttr("tasklite_task.test", "title", "Renamed task"),
+			},
+		},
+	})
+}
+
+func TestAccTaskResourcePatch(t *testing.T) {
+	server := tasklitetest.NewServer(t)
+	config := func(title string, complete bool) string {
+		return fmt.Sprintf(`
+provider "tasklite" {
+  host = "%s"
+}
+
+resource "tasklite_task" "test" {
+  title    = "%s"
+  priority = 2
+  complete = %t
+}
+`, server.URL, title, complete)
+	}
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("Patched task", false),
+			},
+			{
+				Config: config("Patched task", true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("tasklite_task.test", "complete", "true"),
+					resource.TestCheckResourceAttr("tasklite_task.test", "priority", "2"),
+					func(_ *terraform.State) error {
+						var patches []string
+						for _, r := range server.Requests() {
+							if r.Method == http.MethodPatch {
+								patches = append(patches, r.Body)
+							}
+						}
+						if len(patches) != 1 || patches[0] != `{"complete":true}` {
+							return fmt.Errorf("expected a single patch of complete, got %q", patches)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccTaskResourcePatchNotAllowed(t *testing.T) {
+	server := tasklitetest.NewServer(t)
+	// a legacy server rejecting PATCH
+	server.Inject(fakeserver.OnMethod(http.MethodPatch, fakeserver.Fail(http.StatusMethodNotAllowed)))
+	config := func(title string) string {
+		return fmt.Sprintf(`
+provider "tasklite" {
+  host = "%s"
+}
+
+resource "tasklite_task" "test" {
+  title = "%s"
+}
+`, server.URL, title)
+	}
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("Legacy task"),
+			},
+			{
+				Config: config("Renamed legacy task"),
+				Check:  resource.TestCheckResourceAttr("tasklite_task.test", "title", "Renamed legacy task"),
+			},
+		},
+	})
+}
+
+func TestChangedFields(t *testing.T) {
+	state := taskModel{ID: types.Int32Value(1), Title: types.StringValue("Task"), Priority: types.Int32Value(1), Complete: types.BoolValue(false)}
+
+	plan := state
+	assert.Empty(t, changedFields(plan, state))
+
+	plan.Title = types.StringValue("Renamed task")
+	plan.Complete = types.BoolValue(true)
+	assert.Equal(t, map[string]any{"title": "Renamed task", "complete": true}, changedFields(plan, state))
+}
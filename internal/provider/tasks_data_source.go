@@ -0,0 +1,229 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-tasklite/internal/task"
+)
+
+var (
+	_ datasource.DataSource              = &tasksDataSource{}
+	_ datasource.DataSourceWithConfigure = &tasksDataSource{}
+)
+
+const (
+	sortByID       = "id"
+	sortByTitle    = "title"
+	sortByPriority = "priority"
+)
+
+func NewTasksDataSource() datasource.DataSource {
+	return &tasksDataSource{}
+}
+
+type tasksDataSource struct {
+	client task.ClientInterface
+}
+
+// Metadata returns the data source type name.
+func (d *tasksDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tasks"
+}
+
+// Schema defines the schema for the data source.
+func (d *tasksDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists tasks, optionally filtered by title, completion and priority.",
+		Attributes: map[string]schema.Attribute{
+			"title_contains": schema.StringAttribute{
+				Description: "Only include tasks whose title, as stored in TaskLite, contains this substring.",
+				Optional:    true,
+			},
+			"title_regex": schema.StringAttribute{
+				Description: "Only include tasks whose title, as stored in TaskLite, matches this regular expression.",
+				Optional:    true,
+			},
+			"complete": schema.BoolAttribute{
+				Description: "Only include tasks with this completion status.",
+				Optional:    true,
+			},
+			"priority_min": schema.Int32Attribute{
+				Description: "Only include tasks with a priority greater than or equal to this value.",
+				Optional:    true,
+			},
+			"priority_max": schema.Int32Attribute{
+				Description: "Only include tasks with a priority less than or equal to this value.",
+				Optional:    true,
+			},
+			"sort_by": schema.StringAttribute{
+				Description: "Attribute used to order the tasks, one of `id`, `title` or `priority`. Default is `id`. Ties are broken by `id`.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(sortByID, sortByTitle, sortByPriority),
+				},
+			},
+			"sort_descending": schema.BoolAttribute{
+				Description: "Order the tasks in descending order. Default is false",
+				Optional:    true,
+			},
+			"tasks": schema.ListNestedAttribute{
+				Description: "Tasks matching the filters.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int32Attribute{
+							Description: "Numeric identifier of the task.",
+							Computed:    true,
+						},
+						"title": schema.StringAttribute{
+							Description: "Title of the task, as stored in TaskLite: it includes the provider `defaults.title_prefix` of tasks managed by `tasklite_task`.",
+							Computed:    true,
+						},
+						"priority": schema.Int32Attribute{
+							Description: "Priority of the task.",
+							Computed:    true,
+						},
+						"complete": schema.BoolAttribute{
+							Description: "Whether the task is complete.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *tasksDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*task.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *tasklite.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *tasksDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config tasksDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var titleRegex *regexp.Regexp
+	if !config.TitleRegex.IsNull() {
+		re, err := regexp.Compile(config.TitleRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("title_regex"),
+				"Invalid Title Regular Expression",
+				fmt.Sprintf("The title_regex value could not be compiled: %s", err),
+			)
+			return
+		}
+		titleRegex = re
+	}
+
+	if !config.PriorityMin.IsNull() && !config.PriorityMax.IsNull() && config.PriorityMin.ValueInt32() > config.PriorityMax.ValueInt32() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("priority_min"),
+			"Invalid Priority Range",
+			fmt.Sprintf("priority_min (%d) must be less than or equal to priority_max (%d).", config.PriorityMin.ValueInt32(), config.PriorityMax.ValueInt32()),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, "Listing tasks")
+
+	tasks, err := d.client.ListTasks(ctx)
+
+	if err != nil {
+		addErrorDiagnostic(&resp.Diagnostics, "Read", err)
+		tflog.Error(ctx, "Failed to List the tasks", map[string]interface{}{"error": err})
+		return
+	}
+
+	tasks = filterTasks(tasks, config, titleRegex)
+	sortTasks(tasks, config.SortBy.ValueString(), config.SortDescending.ValueBool())
+
+	tflog.Debug(ctx, "Listed tasks", map[string]any{"count": len(tasks)})
+
+	config.Tasks = make([]taskModel, 0, len(tasks))
+	for i := range tasks {
+		config.Tasks = append(config.Tasks, mapTaskToTaskModel(&tasks[i]))
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
+
+// filterTasks returns the tasks matching every filter set in the configuration.
+func filterTasks(tasks []task.Task, config tasksDataSourceModel, titleRegex *regexp.Regexp) []task.Task {
+	filtered := make([]task.Task, 0, len(tasks))
+	for _, t := range tasks {
+		if !config.TitleContains.IsNull() && !strings.Contains(t.Title, config.TitleContains.ValueString()) {
+			continue
+		}
+		if titleRegex != nil && !titleRegex.MatchString(t.Title) {
+			continue
+		}
+		if !config.Complete.IsNull() && t.Complete != config.Complete.ValueBool() {
+			continue
+		}
+		if !config.PriorityMin.IsNull() && t.Priority < config.PriorityMin.ValueInt32() {
+			continue
+		}
+		if !config.PriorityMax.IsNull() && t.Priority > config.PriorityMax.ValueInt32() {
+			continue
+		}
+		filtered = append(filtered, t)
+	}
+
+	return filtered
+}
+
+// sortTasks orders the tasks by the given attribute, breaking ties by ID so the
+// result does not depend on the order returned by the API.
+func sortTasks(tasks []task.Task, sortBy string, descending bool) {
+	less := func(a, b task.Task) bool {
+		switch sortBy {
+		case sortByTitle:
+			if a.Title != b.Title {
+				return a.Title < b.Title
+			}
+		case sortByPriority:
+			if a.Priority != b.Priority {
+				return a.Priority < b.Priority
+			}
+		}
+		return a.ID < b.ID
+	}
+
+	sort.SliceStable(tasks, func(i, j int) bool {
+		if descending {
+			return less(tasks[j], tasks[i])
+		}
+		return less(tasks[i], tasks[j])
+	})
+}
@@ -0,0 +1,143 @@
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+
+	"terraform-provider-tasklite/internal/task"
+)
+
+func newTasksDataSourceServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet && r.URL.Path == task.VERSION_URI {
+			_, _ = w.Write([]byte(`{"version":"1.4.0","capabilities":["idempotency_keys"]}`))
+			return
+		}
+		if r.Method != http.MethodGet || r.URL.Path != "/api/task/" {
+			t.Fatal("Unexpected " + r.Method + " " + r.RequestURI)
+		}
+		_, _ = w.Write([]byte(`[
+			{"id":4,"title":"Write docs","priority":3,"complete":false},
+			{"id":1,"title":"Fix bug","priority":5,"complete":false},
+			{"id":2,"title":"Fix typo","priority":1,"complete":false},
+			{"id":3,"title":"Release","priority":5,"complete":true}
+		]`))
+	}))
+}
+
+func TestAccTasksDataSource(t *testing.T) {
+	server := newTasksDataSourceServer(t)
+	defer server.Close()
+	dataSourceName := "data.tasklite_tasks.test"
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// List all tasks ordered by id
+			{
+				Config: fmt.Sprintf(`
+provider "tasklite" {
+  host = "%s"
+}
+
+data "tasklite_tasks" "test" {}
+`, server.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "tasks.#", "4"),
+					resource.TestCheckResourceAttr(dataSourceName, "tasks.0.id", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "tasks.3.id", "4"),
+				),
+			},
+			// Filter incomplete tasks with priority >= 3
+			{
+				Config: fmt.Sprintf(`
+provider "tasklite" {
+  host = "%s"
+}
+
+data "tasklite_tasks" "test" {
+  complete        = false
+  priority_min    = 3
+  sort_by         = "priority"
+  sort_descending = true
+}
+`, server.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "tasks.#", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "tasks.0.id", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "tasks.0.title", "Fix bug"),
+					resource.TestCheckResourceAttr(dataSourceName, "tasks.1.id", "4"),
+				),
+			},
+			// Invalid regular expression
+			{
+				Config: fmt.Sprintf(`
+provider "tasklite" {
+  host = "%s"
+}
+
+data "tasklite_tasks" "test" {
+  title_regex = "("
+}
+`, server.URL),
+				ExpectError: regexp.MustCompile("Invalid Title Regular Expression"),
+			},
+		},
+	})
+}
+
+func TestAccTasksDataSourceListError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":"forbidden"}`, http.StatusForbidden)
+	}))
+	defer server.Close()
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "tasklite" {
+  host              = "%s"
+  skip_health_check = true
+}
+
+data "tasklite_tasks" "test" {}
+`, server.URL),
+				ExpectError: regexp.MustCompile("TaskLite Authorization Error"),
+			},
+		},
+	})
+}
+
+func TestFilterAndSortTasks(t *testing.T) {
+	tasks := []task.Task{
+		{ID: 3, Title: "Fix typo", Priority: 1, Complete: false},
+		{ID: 1, Title: "Fix bug", Priority: 5, Complete: false},
+		{ID: 2, Title: "Release", Priority: 5, Complete: true},
+		{ID: 4, Title: "Fix build", Priority: 5, Complete: false},
+	}
+
+	config := tasksDataSourceModel{
+		TitleContains: types.StringValue("Fix"),
+		Complete:      types.BoolValue(false),
+		PriorityMin:   types.Int32Value(2),
+		PriorityMax:   types.Int32Null(),
+	}
+
+	filtered := filterTasks(tasks, config, regexp.MustCompile("^Fix b"))
+	sortTasks(filtered, sortByPriority, false)
+
+	assert.Equal(t, []task.Task{
+		{ID: 1, Title: "Fix bug", Priority: 5, Complete: false},
+		{ID: 4, Title: "Fix build", Priority: 5, Complete: false},
+	}, filtered)
+
+	sortTasks(tasks, sortByTitle, true)
+	assert.Equal(t, []int32{2, 3, 4, 1}, []int32{tasks[0].ID, tasks[1].ID, tasks[2].ID, tasks[3].ID})
+}
@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	sdkresource "go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Environment variables configuring the export of traces.
+const (
+	// traceExporterEnvVar selects the exporter: "otlp" or "file".
+	traceExporterEnvVar = "TASKLITE_TRACE_EXPORTER"
+	// traceFileEnvVar is the file the "file" exporter appends spans to.
+	traceFileEnvVar = "TASKLITE_TRACE_FILE"
+)
+
+// tracerName identifies the spans of the resources.
+const tracerName = "terraform-provider-tasklite/internal/provider"
+
+// Attributes of the resource spans.
+const (
+	attrOperation = attribute.Key("tasklite.operation")
+	attrTaskID    = attribute.Key("tasklite.task.id")
+)
+
+// InitTracing registers a global tracer provider exporting the spans of the provider, as
+// set by TASKLITE_TRACE_EXPORTER: "otlp" sends them over OTLP/HTTP, configured with the
+// standard OTEL_EXPORTER_OTLP_* variables, and "file" appends them as JSON to the file
+// named by TASKLITE_TRACE_FILE. Nothing is exported when it is not set. The returned
+// function flushes the remaining spans, and must be called before the provider exits.
+func InitTracing(ctx context.Context, version string) (func(context.Context) error, error) {
+	var exporter sdktrace.SpanExporter
+	var closeFile func() error
+	switch name := os.Getenv(traceExporterEnvVar); name {
+	case "":
+		return func(context.Context) error { return nil }, nil
+	case "otlp":
+		var err error
+		if exporter, err = otlptracehttp.New(ctx); err != nil {
+			return nil, fmt.Errorf("failed to create the OTLP trace exporter: %w", err)
+		}
+	case "file":
+		path := os.Getenv(traceFileEnvVar)
+		if path == "" {
+			return nil, fmt.Errorf("%s must be set with %s=file", traceFileEnvVar, traceExporterEnvVar)
+		}
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open the trace file: %w", err)
+		}
+		if exporter, err = stdouttrace.New(stdouttrace.WithWriter(f)); err != nil {
+			_ = f.Close()
+			return nil, fmt.Errorf("failed to create the file trace exporter: %w", err)
+		}
+		closeFile = f.Close
+	default:
+		return nil, fmt.Errorf("invalid %s %q, expected \"otlp\" or \"file\"", traceExporterEnvVar, name)
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(sdkresource.NewSchemaless(
+			attribute.String("service.name", "terraform-provider-tasklite"),
+			attribute.String("service.version", version),
+		)),
+	)
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	return func(ctx context.Context) error {
+		err := tp.Shutdown(ctx)
+		if closeFile != nil {
+			err = errors.Join(err, closeFile())
+		}
+		return err
+	}, nil
+}
+
+// startSpan starts the span of a resource operation, such as "create".
+func startSpan(ctx context.Context, operation string) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, "tasklite_task."+operation, trace.WithAttributes(attrOperation.String(operation)))
+}
+
+// setSpanTaskID records the ID of the task of the operation traced in ctx, when known.
+func setSpanTaskID(ctx context.Context, id types.Int32) {
+	if id.IsNull() || id.IsUnknown() {
+		return
+	}
+	trace.SpanFromContext(ctx).SetAttributes(attrTaskID.Int(int(id.ValueInt32())))
+}
+
+// endSpan ends span, marking it failed with the first error of diags.
+func endSpan(span trace.Span, diags diag.Diagnostics) {
+	if errs := diags.Errors(); len(errs) > 0 {
+		span.SetStatus(codes.Error, errs[0].Summary())
+	}
+	span.End()
+}
@@ -0,0 +1,160 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"terraform-provider-tasklite/internal/task/tasklitetest"
+)
+
+// setGlobalTracerProvider registers tp as the global tracer provider for the duration of
+// the test.
+func setGlobalTracerProvider(t *testing.T, tp *sdktrace.TracerProvider) {
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(tp)
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+}
+
+func TestInitTracingDisabled(t *testing.T) {
+	t.Setenv(traceExporterEnvVar, "")
+
+	shutdown, err := InitTracing(context.Background(), "test")
+
+	assert.NoError(t, err)
+	assert.NoError(t, shutdown(context.Background()))
+}
+
+func TestInitTracingInvalid(t *testing.T) {
+	t.Setenv(traceExporterEnvVar, "zipkin")
+	_, err := InitTracing(context.Background(), "test")
+	assert.ErrorContains(t, err, `invalid TASKLITE_TRACE_EXPORTER "zipkin"`)
+
+	t.Setenv(traceExporterEnvVar, "file")
+	t.Setenv(traceFileEnvVar, "")
+	_, err = InitTracing(context.Background(), "test")
+	assert.ErrorContains(t, err, "TASKLITE_TRACE_FILE must be set")
+}
+
+func TestInitTracingFile(t *testing.T) {
+	setGlobalTracerProvider(t, sdktrace.NewTracerProvider())
+	path := filepath.Join(t.TempDir(), "traces.json")
+	t.Setenv(traceExporterEnvVar, "file")
+	t.Setenv(traceFileEnvVar, path)
+
+	shutdown, err := InitTracing(context.Background(), "test")
+	assert.NoError(t, err)
+	ctx, span := startSpan(context.Background(), "create")
+	setSpanTaskID(ctx, types.Int32Value(42))
+	endSpan(span, nil)
+	assert.NoError(t, shutdown(context.Background()))
+
+	traces, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Contains(t, string(traces), `"Name":"tasklite_task.create"`)
+	assert.Contains(t, string(traces), `"Key":"tasklite.task.id"`)
+}
+
+func TestInitTracingOTLP(t *testing.T) {
+	setGlobalTracerProvider(t, sdktrace.NewTracerProvider())
+	var exported atomic.Int32
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/traces" {
+			exported.Add(1)
+		}
+	}))
+	defer collector.Close()
+	t.Setenv(traceExporterEnvVar, "otlp")
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", collector.URL)
+
+	shutdown, err := InitTracing(context.Background(), "test")
+	assert.NoError(t, err)
+	_, span := startSpan(context.Background(), "read")
+	endSpan(span, nil)
+	assert.NoError(t, shutdown(context.Background()))
+
+	assert.Equal(t, int32(1), exported.Load())
+}
+
+func TestEndSpan(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	setGlobalTracerProvider(t, sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	var diags diag.Diagnostics
+	diags.AddWarning("Some Warning", "detail")
+	diags.AddError("Task Changed Outside Terraform", "detail")
+
+	_, span := startSpan(context.Background(), "update")
+	endSpan(span, diags)
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, "Task Changed Outside Terraform", spans[0].Status().Description)
+}
+
+func TestAccTaskResourceTracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	setGlobalTracerProvider(t, sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	server := tasklitetest.NewServer(t)
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "tasklite" {
+  host = "%s"
+}
+
+resource "tasklite_task" "test" {
+  title = "Traced task"
+}
+`, server.URL),
+				Check: func(_ *terraform.State) error {
+					for _, create := range recorder.Ended() {
+						if create.Name() != "tasklite_task.create" {
+							continue
+						}
+						var id int64
+						for _, kv := range create.Attributes() {
+							if kv.Key == attrTaskID {
+								id = kv.Value.AsInt64()
+							}
+						}
+						if id != 1 {
+							return fmt.Errorf("expected task ID 1 on the create span, got %d", id)
+						}
+						for _, request := range recorder.Ended() {
+							if request.Name() == "POST /api/task/" && request.Parent().SpanID() == create.SpanContext().SpanID() {
+								return nil
+							}
+						}
+						return fmt.Errorf("no request span under the create span")
+					}
+					return fmt.Errorf("no create span recorded")
+				},
+			},
+		},
+	})
+
+	// the trace context was propagated to TaskLite in the traceparent header
+	for _, r := range server.Requests() {
+		if r.Method == http.MethodPost {
+			assert.Regexp(t, "^00-[0-9a-f]{32}-[0-9a-f]{16}-01$", r.Header.Get("traceparent"))
+		}
+	}
+}
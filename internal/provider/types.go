@@ -1,6 +1,9 @@
 package provider
 
 import (
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"terraform-provider-tasklite/internal/task"
@@ -9,11 +12,67 @@ import (
 // taskLiteProvider is the provider implementation.
 type taskLiteProvider struct {
 	version string
+	// clientOptions are applied to the client after the options of the configuration.
+	clientOptions []task.Option
 }
 
 // hashicupsProviderModel maps provider schema data to a Go type.
 type hashicupsProviderModel struct {
-	Host types.String `tfsdk:"host"`
+	Host                    types.String  `tfsdk:"host"`
+	Hosts                   types.List    `tfsdk:"hosts"`
+	HostSelection           types.String  `tfsdk:"host_selection"`
+	HealthCheckPath         types.String  `tfsdk:"health_check_path"`
+	SkipHealthCheck         types.Bool    `tfsdk:"skip_health_check"`
+	MaxRetries              types.Int64   `tfsdk:"max_retries"`
+	RetryMaxWait            types.String  `tfsdk:"retry_max_wait"`
+	RequestTimeout          types.String  `tfsdk:"request_timeout"`
+	RequestsPerSecond       types.Float64 `tfsdk:"requests_per_second"`
+	MaxConcurrentRequests   types.Int64   `tfsdk:"max_concurrent_requests"`
+	CircuitBreakerThreshold types.Int64   `tfsdk:"circuit_breaker_threshold"`
+	CircuitBreakerCooldown  types.String  `tfsdk:"circuit_breaker_cooldown"`
+	Token                   types.String  `tfsdk:"token"`
+	Username                types.String  `tfsdk:"username"`
+	Password                types.String  `tfsdk:"password"`
+	Headers                 types.Map     `tfsdk:"headers"`
+
+	CACertPEM          types.String `tfsdk:"ca_cert_pem"`
+	CACertFile         types.String `tfsdk:"ca_cert_file"`
+	ClientCertPEM      types.String `tfsdk:"client_cert_pem"`
+	ClientKeyPEM       types.String `tfsdk:"client_key_pem"`
+	TLSServerName      types.String `tfsdk:"tls_server_name"`
+	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
+
+	RedactedLogFields types.List `tfsdk:"redacted_log_fields"`
+
+	MinPriority      types.Int32 `tfsdk:"min_priority"`
+	MaxPriority      types.Int32 `tfsdk:"max_priority"`
+	StrictValidation types.Bool  `tfsdk:"strict_validation"`
+
+	Defaults *taskDefaultsModel `tfsdk:"defaults"`
+	OAuth2   *oauth2Model       `tfsdk:"oauth2"`
+}
+
+// taskDefaultsModel maps the provider defaults block.
+type taskDefaultsModel struct {
+	Priority    types.Int32  `tfsdk:"priority"`
+	Complete    types.Bool   `tfsdk:"complete"`
+	TitlePrefix types.String `tfsdk:"title_prefix"`
+}
+
+// oauth2Model maps the provider oauth2 block.
+type oauth2Model struct {
+	TokenURL     types.String `tfsdk:"token_url"`
+	ClientID     types.String `tfsdk:"client_id"`
+	ClientSecret types.String `tfsdk:"client_secret"`
+	Scopes       types.List   `tfsdk:"scopes"`
+	Audience     types.String `tfsdk:"audience"`
+}
+
+// taskResourceData is the provider data of the tasklite_task resource.
+type taskResourceData struct {
+	client   *task.Client
+	priority priorityValidator
+	defaults taskDefaultsModel
 }
 
 type taskModel struct {
@@ -23,6 +82,44 @@ type taskModel struct {
 	Complete types.Bool   `tfsdk:"complete"`
 }
 
+// taskResourceModel maps the tasklite_task resource schema data.
+type taskResourceModel struct {
+	taskModel
+	FullTitle types.String `tfsdk:"full_title"`
+	// PendingIdempotencyKey is the key of a create TaskLite has not confirmed.
+	PendingIdempotencyKey types.String   `tfsdk:"pending_idempotency_key"`
+	Timeouts              timeouts.Value `tfsdk:"timeouts"`
+}
+
+// apiTaskModel returns the task attributes of m as sent to TaskLite, titled with the full title.
+func (m taskResourceModel) apiTaskModel() taskModel {
+	t := m.taskModel
+	if !m.FullTitle.IsNull() && !m.FullTitle.IsUnknown() {
+		t.Title = m.FullTitle
+	}
+	return t
+}
+
+// setTask sets the task attributes of m to those of t, as sent by TaskLite, with
+// titlePrefix removed from the title.
+func (m *taskResourceModel) setTask(t *task.Task, titlePrefix string) {
+	m.taskModel = mapTaskToTaskModel(t)
+	m.Title = types.StringValue(strings.TrimPrefix(t.Title, titlePrefix))
+	m.FullTitle = types.StringValue(t.Title)
+}
+
+// tasksDataSourceModel maps the tasklite_tasks data source schema data.
+type tasksDataSourceModel struct {
+	TitleContains  types.String `tfsdk:"title_contains"`
+	TitleRegex     types.String `tfsdk:"title_regex"`
+	Complete       types.Bool   `tfsdk:"complete"`
+	PriorityMin    types.Int32  `tfsdk:"priority_min"`
+	PriorityMax    types.Int32  `tfsdk:"priority_max"`
+	SortBy         types.String `tfsdk:"sort_by"`
+	SortDescending types.Bool   `tfsdk:"sort_descending"`
+	Tasks          []taskModel  `tfsdk:"tasks"`
+}
+
 // mapTaskToTaskModel maps api client task struct to provider task type.
 func mapTaskToTaskModel(t *task.Task) taskModel {
 	return taskModel{
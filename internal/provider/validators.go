@@ -0,0 +1,133 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// maxTitleLength is the maximum number of characters of a task title.
+const maxTitleLength = 255
+
+var (
+	_ validator.String = titleValidator{}
+	_ validator.Int32  = priorityValidator{}
+)
+
+// titleValidator checks that a task title is not empty, does not begin or end with
+// whitespace, and is at most maxLength characters long.
+type titleValidator struct {
+	maxLength int
+}
+
+func (v titleValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("title must not be empty, must not begin or end with whitespace, and must be at most %d characters long", v.maxLength)
+}
+
+func (v titleValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v titleValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	title := req.ConfigValue.ValueString()
+	switch {
+	case strings.TrimSpace(title) == "":
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Task Title", "The title must not be empty.")
+	case strings.TrimSpace(title) != title:
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Task Title",
+			fmt.Sprintf("The title must not begin or end with whitespace, got: %q", title),
+		)
+	case utf8.RuneCountInString(title) > v.maxLength:
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Task Title",
+			fmt.Sprintf("The title must be at most %d characters long, got: %d characters.", v.maxLength, utf8.RuneCountInString(title)),
+		)
+	}
+}
+
+// priorityValidator checks that a task priority is within the range accepted by TaskLite.
+// Out of range priorities are only reported as warnings when it is not strict. The zero
+// value only accepts 0; use priorityValidatorFromConfig for a validator accepting any
+// priority unless a range is configured.
+type priorityValidator struct {
+	minPriority, maxPriority int32
+	strict                   bool
+}
+
+func (v priorityValidator) Description(_ context.Context) string {
+	return "priority must be " + v.rangeDescription()
+}
+
+// rangeDescription describes the accepted priorities, e.g. "at least 0".
+func (v priorityValidator) rangeDescription() string {
+	switch {
+	case v.minPriority == math.MinInt32 && v.maxPriority == math.MaxInt32:
+		return "any number"
+	case v.minPriority == math.MinInt32:
+		return fmt.Sprintf("at most %d", v.maxPriority)
+	case v.maxPriority == math.MaxInt32:
+		return fmt.Sprintf("at least %d", v.minPriority)
+	default:
+		return fmt.Sprintf("between %d and %d", v.minPriority, v.maxPriority)
+	}
+}
+
+func (v priorityValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v priorityValidator) ValidateInt32(_ context.Context, req validator.Int32Request, resp *validator.Int32Response) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	priority := req.ConfigValue.ValueInt32()
+	if priority >= v.minPriority && priority <= v.maxPriority {
+		return
+	}
+	detail := fmt.Sprintf("The priority must be %s, as configured by the provider min_priority and max_priority, got: %d.", v.rangeDescription(), priority)
+	if v.strict {
+		resp.Diagnostics.AddAttributeError(req.Path, "Task Priority Out of Range", detail)
+	} else {
+		resp.Diagnostics.AddAttributeWarning(req.Path, "Task Priority Out of Range", detail+" TaskLite may reject it.")
+	}
+}
+
+// priorityValidatorFromConfig returns the validator of the task priorities configured by
+// the provider min_priority, max_priority and strict_validation attributes. Without them,
+// any priority is accepted.
+func priorityValidatorFromConfig(config hashicupsProviderModel) (priorityValidator, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	v := priorityValidator{minPriority: math.MinInt32, maxPriority: math.MaxInt32, strict: true}
+	if !config.MinPriority.IsNull() {
+		v.minPriority = config.MinPriority.ValueInt32()
+	}
+	if !config.MaxPriority.IsNull() {
+		v.maxPriority = config.MaxPriority.ValueInt32()
+	}
+	if !config.StrictValidation.IsNull() {
+		v.strict = config.StrictValidation.ValueBool()
+	}
+
+	if v.minPriority > v.maxPriority {
+		diags.AddAttributeError(
+			path.Root("min_priority"),
+			"Invalid Priority Range",
+			fmt.Sprintf("min_priority (%d) must not be greater than max_priority (%d).", v.minPriority, v.maxPriority),
+		)
+	}
+	return v, diags
+}
@@ -0,0 +1,157 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+
+	"terraform-provider-tasklite/internal/task/tasklitetest"
+)
+
+func TestTitleValidator(t *testing.T) {
+	v := titleValidator{maxLength: 5}
+	for title, want := range map[types.String]string{
+		types.StringValue("Task"):   "",
+		types.StringValue("Tâche"):  "",
+		types.StringNull():          "",
+		types.StringUnknown():       "",
+		types.StringValue(""):       "The title must not be empty.",
+		types.StringValue(" \t"):    "The title must not be empty.",
+		types.StringValue(" Task"):  `The title must not begin or end with whitespace, got: " Task"`,
+		types.StringValue("Task\n"): `The title must not begin or end with whitespace, got: "Task\n"`,
+		types.StringValue("Tasks!"): "The title must be at most 5 characters long, got: 6 characters.",
+	} {
+		resp := validator.StringResponse{}
+		v.ValidateString(context.Background(), validator.StringRequest{Path: path.Root("title"), ConfigValue: title}, &resp)
+
+		if want == "" {
+			assert.Empty(t, resp.Diagnostics, title.String())
+			continue
+		}
+		assert.Equal(t, diag.Diagnostics{diag.NewAttributeErrorDiagnostic(path.Root("title"), "Invalid Task Title", want)}, resp.Diagnostics, title.String())
+	}
+}
+
+func TestPriorityValidator(t *testing.T) {
+	for _, tc := range []struct {
+		validator priorityValidator
+		priority  types.Int32
+		severity  diag.Severity
+	}{
+		{priorityValidator{minPriority: 0, maxPriority: 10, strict: true}, types.Int32Value(0), diag.SeverityInvalid},
+		{priorityValidator{minPriority: 0, maxPriority: 10, strict: true}, types.Int32Value(10), diag.SeverityInvalid},
+		{priorityValidator{minPriority: 0, maxPriority: 10, strict: true}, types.Int32Null(), diag.SeverityInvalid},
+		{priorityValidator{minPriority: 0, maxPriority: 10, strict: true}, types.Int32Unknown(), diag.SeverityInvalid},
+		{priorityValidator{minPriority: 0, maxPriority: 10, strict: true}, types.Int32Value(-1), diag.SeverityError},
+		{priorityValidator{minPriority: 0, maxPriority: 10, strict: true}, types.Int32Value(11), diag.SeverityError},
+		{priorityValidator{minPriority: 0, maxPriority: 10, strict: false}, types.Int32Value(11), diag.SeverityWarning},
+	} {
+		resp := validator.Int32Response{}
+		tc.validator.ValidateInt32(context.Background(), validator.Int32Request{Path: path.Root("priority"), ConfigValue: tc.priority}, &resp)
+
+		name := fmt.Sprintf("%+v %s", tc.validator, tc.priority)
+		if tc.severity == diag.SeverityInvalid {
+			assert.Empty(t, resp.Diagnostics, name)
+			continue
+		}
+		if assert.Len(t, resp.Diagnostics, 1, name) {
+			assert.Equal(t, tc.severity, resp.Diagnostics[0].Severity(), name)
+			assert.Equal(t, "Task Priority Out of Range", resp.Diagnostics[0].Summary(), name)
+		}
+	}
+}
+
+func TestPriorityValidatorFromConfig(t *testing.T) {
+	v, diags := priorityValidatorFromConfig(hashicupsProviderModel{})
+	assert.Empty(t, diags)
+	assert.Equal(t, priorityValidator{minPriority: math.MinInt32, maxPriority: math.MaxInt32, strict: true}, v)
+	assert.Equal(t, "priority must be any number", v.Description(context.Background()))
+
+	v, diags = priorityValidatorFromConfig(hashicupsProviderModel{
+		MinPriority:      types.Int32Value(-5),
+		MaxPriority:      types.Int32Value(5),
+		StrictValidation: types.BoolValue(false),
+	})
+	assert.Empty(t, diags)
+	assert.Equal(t, priorityValidator{minPriority: -5, maxPriority: 5, strict: false}, v)
+
+	_, diags = priorityValidatorFromConfig(hashicupsProviderModel{MinPriority: types.Int32Value(5), MaxPriority: types.Int32Value(1)})
+	assert.True(t, diags.HasError())
+}
+
+func TestAccTaskResourceValidation(t *testing.T) {
+	server := tasklitetest.NewServer(t)
+	config := func(providerConfig, title string, priority int) string {
+		return fmt.Sprintf(`
+provider "tasklite" {
+  host = "%s"
+  %s
+}
+
+resource "tasklite_task" "test" {
+  title    = %q
+  priority = %d
+}
+`, server.URL, providerConfig, title, priority)
+	}
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      config("", " ", 1),
+				ExpectError: regexp.MustCompile("The title must not be empty"),
+			},
+			{
+				Config:      config("", strings.Repeat("a", maxTitleLength+1), 1),
+				ExpectError: regexp.MustCompile("The title must be at most 255 characters long"),
+			},
+			{
+				Config:      config("defaults {\n    title_prefix = \"[ops] \"\n  }", strings.Repeat("a", maxTitleLength-1), 1),
+				ExpectError: regexp.MustCompile(`(?s)prefixed with the provider defaults.title_prefix.*got: 260`),
+			},
+			// the priority range is only checked when configured
+			{
+				Config:             config("", "Task", -1),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config:      config("min_priority = 0", "Task", -1),
+				ExpectError: regexp.MustCompile(`(?s)The priority must be at least 0.*got: -1`),
+			},
+			{
+				Config:      config("min_priority = 0\n  max_priority = 5", "Task", 6),
+				ExpectError: regexp.MustCompile(`(?s)The priority must be between 0 and 5.*got: 6`),
+			},
+			{
+				Config:      config("min_priority = 5\n  max_priority = 1", "Task", 1),
+				ExpectError: regexp.MustCompile("Invalid Priority Range"),
+			},
+			{
+				// out of range priorities are left to TaskLite without strict validation
+				Config: config("max_priority = 5\n  strict_validation = false", "Task", 6),
+				Check:  resource.TestCheckResourceAttr("tasklite_task.test", "priority", "6"),
+			},
+		},
+	})
+
+	// only the task planned without strict validation reached TaskLite
+	var creates int
+	for _, r := range server.Requests() {
+		if r.Method == http.MethodPost {
+			creates++
+		}
+	}
+	assert.Equal(t, 1, creates)
+}
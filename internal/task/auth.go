@@ -0,0 +1,114 @@
+package task
+
+import (
+	"net/http"
+)
+
+// Authenticator adds credentials to an outgoing request. It is called on every attempt,
+// so it may refresh short-lived credentials.
+type Authenticator interface {
+	Authenticate(req *http.Request) error
+}
+
+// RefreshableAuthenticator is an Authenticator whose credentials can be discarded when the
+// server rejects them, e.g. a revoked access token. Requests answered with 401 are sent
+// once more after Invalidate is called.
+type RefreshableAuthenticator interface {
+	Authenticator
+	Invalidate()
+}
+
+// transportSetter is implemented by authenticators sending requests of their own, such as
+// token requests, so they use the client transport without authentication.
+type transportSetter interface {
+	setTransport(http.RoundTripper)
+}
+
+// AuthenticatorFunc adapts a function to an Authenticator.
+type AuthenticatorFunc func(req *http.Request) error
+
+func (f AuthenticatorFunc) Authenticate(req *http.Request) error {
+	return f(req)
+}
+
+// BearerTokenAuth sends token in the Authorization header.
+func BearerTokenAuth(token string) Authenticator {
+	return AuthenticatorFunc(func(req *http.Request) error {
+		req.Header.Set("Authorization", "Bearer "+token)
+		return nil
+	})
+}
+
+// BasicAuth sends username and password using HTTP basic authentication.
+func BasicAuth(username, password string) Authenticator {
+	return AuthenticatorFunc(func(req *http.Request) error {
+		req.SetBasicAuth(username, password)
+		return nil
+	})
+}
+
+// HeadersAuth sets the given headers on every request.
+func HeadersAuth(headers map[string]string) Authenticator {
+	return AuthenticatorFunc(func(req *http.Request) error {
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		return nil
+	})
+}
+
+// WithAuthenticator adds an authenticator applied to every request, after the ones
+// already added.
+func WithAuthenticator(a Authenticator) Option {
+	return func(c *Client) {
+		c.authenticators = append(c.authenticators, a)
+	}
+}
+
+// authTransport is an http.RoundTripper applying authenticators to each request.
+type authTransport struct {
+	base           http.RoundTripper
+	authenticators []Authenticator
+}
+
+func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.roundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	refreshed := false
+	for _, a := range t.authenticators {
+		if r, ok := a.(RefreshableAuthenticator); ok {
+			r.Invalidate()
+			refreshed = true
+		}
+	}
+	if !refreshed || (req.Body != nil && req.Body != http.NoBody && req.GetBody == nil) {
+		return resp, nil
+	}
+
+	// retry once with fresh credentials
+	retry := req.Clone(req.Context())
+	if req.GetBody != nil {
+		if retry.Body, err = req.GetBody(); err != nil {
+			return resp, nil
+		}
+	}
+	drainAndClose(resp)
+	return t.roundTrip(retry)
+}
+
+func (t *authTransport) roundTrip(req *http.Request) (*http.Response, error) {
+	// a RoundTripper must not modify the caller's request
+	req = req.Clone(req.Context())
+	for _, a := range t.authenticators {
+		if err := a.Authenticate(req); err != nil {
+			if req.Body != nil {
+				_ = req.Body.Close()
+			}
+			return nil, err
+		}
+	}
+	return t.base.RoundTrip(req)
+}
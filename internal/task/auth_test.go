@@ -0,0 +1,69 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func setupHeaderServer(t *testing.T) (*httptest.Server, *http.Header) {
+	var header http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Clone()
+		_, _ = w.Write([]byte(`{"id":1,"title":"Test Task"}`))
+	}))
+	return server, &header
+}
+
+func TestBearerTokenAuth(t *testing.T) {
+	server, header := setupHeaderServer(t)
+	defer server.Close()
+
+	_, err := NewClient(server.URL, WithAuthenticator(BearerTokenAuth("secret"))).ReadTask(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Bearer secret", header.Get("Authorization"))
+}
+
+func TestBasicAuth(t *testing.T) {
+	server, header := setupHeaderServer(t)
+	defer server.Close()
+
+	_, err := NewClient(server.URL, WithAuthenticator(BasicAuth("user", "pass"))).ReadTask(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Basic dXNlcjpwYXNz", header.Get("Authorization"))
+}
+
+func TestHeadersAuth(t *testing.T) {
+	server, header := setupHeaderServer(t)
+	defer server.Close()
+
+	c := NewClient(server.URL,
+		WithAuthenticator(HeadersAuth(map[string]string{"X-Tenant": "team-a", "Authorization": "overridden"})),
+		WithAuthenticator(BearerTokenAuth("secret")),
+	)
+	_, err := c.ReadTask(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "team-a", header.Get("X-Tenant"))
+	assert.Equal(t, "Bearer secret", header.Get("Authorization"))
+}
+
+func TestAuthenticatorError(t *testing.T) {
+	server, _ := setupHeaderServer(t)
+	defer server.Close()
+
+	authErr := errors.New("no credentials")
+	c := NewClient(server.URL,
+		WithRetryPolicy(RetryPolicy{}),
+		WithAuthenticator(AuthenticatorFunc(func(*http.Request) error { return authErr })),
+	)
+	_, err := c.ReadTask(context.Background(), 1)
+
+	assert.ErrorIs(t, err, authErr)
+}
@@ -0,0 +1,170 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Default circuit breaker settings used by NewClient.
+const (
+	DefaultCircuitBreakerThreshold = 5
+	DefaultCircuitBreakerCooldown  = 30 * time.Second
+)
+
+// ErrCircuitOpen is matched by errors returned without sending the request because the
+// circuit breaker is open.
+var ErrCircuitOpen = errors.New("circuit breaker open")
+
+// CircuitOpenError is returned when the circuit breaker rejects a request after too many
+// consecutive failures.
+type CircuitOpenError struct {
+	// Failures is the number of consecutive failures that opened the circuit.
+	Failures int
+	// LastError describes the last failure.
+	LastError string
+	// RetryAfter is the time left until a request is let through to probe the server.
+	// It is zero while a probe is in flight.
+	RetryAfter time.Duration
+}
+
+func (e *CircuitOpenError) Error() string {
+	msg := fmt.Sprintf("TaskLite is unavailable, circuit breaker open after %d consecutive failures (last error: %s)", e.Failures, e.LastError)
+	if e.RetryAfter > 0 {
+		msg += fmt.Sprintf(", next attempt in %s", e.RetryAfter.Round(time.Second))
+	}
+	return msg
+}
+
+func (e *CircuitOpenError) Is(target error) bool {
+	return target == ErrCircuitOpen
+}
+
+// WithCircuitBreaker opens the circuit after threshold consecutive transport failures or
+// 5xx responses. While open, requests fail immediately with a CircuitOpenError. After
+// cooldown a single request is let through: its success closes the circuit, its failure
+// opens it for another cooldown. A threshold of zero disables the circuit breaker.
+func WithCircuitBreaker(threshold int, cooldown time.Duration) Option {
+	return func(c *Client) {
+		c.breaker.threshold = threshold
+		c.breaker.cooldown = cooldown
+	}
+}
+
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+func (s circuitState) String() string {
+	switch s {
+	case circuitOpen:
+		return "open"
+	case circuitHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+// circuitBreaker tracks consecutive failures of the requests of a client.
+type circuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+
+	mu        sync.Mutex
+	state     circuitState
+	failures  int
+	lastError string
+	retryAt   time.Time
+	probing   bool
+}
+
+// allow returns a CircuitOpenError when a request must not be sent.
+func (b *circuitBreaker) allow(ctx context.Context) error {
+	if b.threshold <= 0 {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case circuitOpen:
+		if wait := time.Until(b.retryAt); wait > 0 {
+			return &CircuitOpenError{Failures: b.failures, LastError: b.lastError, RetryAfter: wait}
+		}
+		b.transition(ctx, circuitHalfOpen)
+	case circuitHalfOpen:
+		if b.probing {
+			return &CircuitOpenError{Failures: b.failures, LastError: b.lastError}
+		}
+	default:
+		return nil
+	}
+
+	b.probing = true
+	return nil
+}
+
+// record updates the breaker with the outcome of a request let through by allow.
+// Requests abandoned by the caller count neither as success nor as failure.
+func (b *circuitBreaker) record(ctx context.Context, resp *http.Response, err error) {
+	if b.threshold <= 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == circuitHalfOpen {
+		b.probing = false
+	}
+
+	switch {
+	case ctx.Err() != nil:
+		return
+	case err == nil && resp.StatusCode < 500:
+		b.failures = 0
+		if b.state != circuitClosed {
+			b.transition(ctx, circuitClosed)
+		}
+		return
+	case err != nil:
+		b.lastError = err.Error()
+	default:
+		b.lastError = resp.Status
+	}
+
+	b.failures++
+	if b.state == circuitHalfOpen || (b.state == circuitClosed && b.failures >= b.threshold) {
+		b.retryAt = time.Now().Add(b.cooldown)
+		b.transition(ctx, circuitOpen)
+	}
+}
+
+// transition changes the state of the breaker. It must be called with b.mu held.
+func (b *circuitBreaker) transition(ctx context.Context, to circuitState) {
+	fields := map[string]any{"from": b.state.String(), "to": to.String()}
+	b.state = to
+
+	switch to {
+	case circuitOpen:
+		fields["failures"] = b.failures
+		fields["last_error"] = b.lastError
+		fields["cooldown"] = b.cooldown.String()
+		tflog.Warn(ctx, "TaskLite circuit breaker opened, failing requests fast", fields)
+	case circuitHalfOpen:
+		tflog.Info(ctx, "TaskLite circuit breaker half-open, probing the server", fields)
+	case circuitClosed:
+		tflog.Info(ctx, "TaskLite circuit breaker closed", fields)
+	}
+}
@@ -0,0 +1,106 @@
+package task
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// setupStatusServer answers every request with the current status, and counts the requests.
+func setupStatusServer(status int) (*httptest.Server, *atomic.Int32, *atomic.Int32) {
+	var current, calls atomic.Int32
+	current.Store(int32(status))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(int(current.Load()))
+		_, _ = w.Write([]byte(`{"id":1,"title":"Test Task"}`))
+	}))
+	return server, &current, &calls
+}
+
+func TestCircuitBreakerOpens(t *testing.T) {
+	server, _, calls := setupStatusServer(http.StatusServiceUnavailable)
+	defer server.Close()
+	client := NewClient(server.URL, WithRetryPolicy(RetryPolicy{}), WithCircuitBreaker(3, time.Hour))
+
+	for i := 0; i < 3; i++ {
+		_, err := client.ReadTask(context.Background(), 1)
+		assert.ErrorContains(t, err, "HTTP 503")
+	}
+	_, err := client.ReadTask(context.Background(), 1)
+
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.ErrorContains(t, err, "circuit breaker open after 3 consecutive failures (last error: 503 Service Unavailable)")
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestCircuitBreakerStopsRetries(t *testing.T) {
+	server, _, calls := setupStatusServer(http.StatusBadGateway)
+	defer server.Close()
+	policy := testRetryPolicy
+	policy.MaxRetries = 10
+	client := NewClient(server.URL, WithRetryPolicy(policy), WithCircuitBreaker(3, time.Hour))
+
+	_, err := client.ReadTask(context.Background(), 1)
+
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestCircuitBreakerHalfOpen(t *testing.T) {
+	server, status, calls := setupStatusServer(http.StatusInternalServerError)
+	defer server.Close()
+	client := NewClient(server.URL, WithRetryPolicy(RetryPolicy{}), WithCircuitBreaker(2, 50*time.Millisecond))
+
+	for i := 0; i < 2; i++ {
+		_, _ = client.ReadTask(context.Background(), 1)
+	}
+	_, err := client.ReadTask(context.Background(), 1)
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+
+	// the probe after the cooldown fails and opens the circuit again
+	time.Sleep(60 * time.Millisecond)
+	_, err = client.ReadTask(context.Background(), 1)
+	assert.ErrorContains(t, err, "HTTP 500")
+	_, err = client.ReadTask(context.Background(), 1)
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.Equal(t, int32(3), calls.Load())
+
+	// the next probe succeeds and closes the circuit
+	status.Store(http.StatusOK)
+	time.Sleep(60 * time.Millisecond)
+	for i := 0; i < 3; i++ {
+		_, err = client.ReadTask(context.Background(), 1)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, int32(6), calls.Load())
+}
+
+func TestCircuitBreakerIgnoresClientErrors(t *testing.T) {
+	server, _, calls := setupStatusServer(http.StatusNotFound)
+	defer server.Close()
+	client := NewClient(server.URL, WithCircuitBreaker(2, time.Hour))
+
+	for i := 0; i < 5; i++ {
+		_, err := client.ReadTask(context.Background(), 1)
+		assert.ErrorIs(t, err, ErrNotFound)
+	}
+	assert.Equal(t, int32(5), calls.Load())
+}
+
+func TestCircuitBreakerDisabled(t *testing.T) {
+	server, _, calls := setupStatusServer(http.StatusServiceUnavailable)
+	defer server.Close()
+	client := NewClient(server.URL, WithRetryPolicy(RetryPolicy{}), WithCircuitBreaker(0, time.Hour))
+
+	for i := 0; i < 10; i++ {
+		_, err := client.ReadTask(context.Background(), 1)
+		assert.ErrorContains(t, err, "HTTP 503")
+	}
+	assert.Equal(t, int32(10), calls.Load())
+}
@@ -0,0 +1,205 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// DefaultHealthCheckPath is requested to decide whether an unhealthy endpoint recovered.
+// It is cheap to serve, unlike the task collection, and legacy servers answering 404 are
+// healthy.
+const DefaultHealthCheckPath = VERSION_URI
+
+const (
+	// healthCheckInterval is the time between health checks of an unhealthy endpoint.
+	healthCheckInterval = 10 * time.Second
+	// healthCheckTimeout limits the duration of a health check.
+	healthCheckTimeout = 5 * time.Second
+)
+
+// WithFailoverEndpoints adds endpoints serving the same TaskLite API as BaseURL. Requests
+// that may safely be sent again fail over to the next endpoint when an endpoint cannot be
+// reached or answers 502, 503 or 504. Failing endpoints are marked unhealthy and tried
+// last until a health check succeeds.
+func WithFailoverEndpoints(baseURLs ...string) Option {
+	return func(c *Client) {
+		c.failoverURLs = append(c.failoverURLs, baseURLs...)
+	}
+}
+
+// WithRoundRobin spreads requests across all healthy endpoints instead of preferring the
+// endpoints in the order they were given.
+func WithRoundRobin() Option {
+	return func(c *Client) {
+		c.endpoints.roundRobin = true
+	}
+}
+
+// WithHealthCheckPath sets the path requested with GET to check an unhealthy endpoint,
+// DefaultHealthCheckPath by default. Any 2xx response marks the endpoint healthy, as does
+// a 404 for DefaultHealthCheckPath, like Probe accepts from legacy servers. When
+// empty, unhealthy endpoints are tried again after a while without a health check.
+func WithHealthCheckPath(path string) Option {
+	return func(c *Client) {
+		c.endpoints.healthCheckPath = path
+	}
+}
+
+// endpoint is a base URL of the TaskLite API.
+type endpoint struct {
+	baseURL    string
+	healthy    bool
+	checkAfter time.Time
+	checking   bool
+}
+
+// endpointPool selects the endpoints requests are sent to.
+type endpointPool struct {
+	roundRobin      bool
+	healthCheckPath string
+	interval        time.Duration
+	httpClient      *http.Client
+
+	mu        sync.Mutex
+	endpoints []*endpoint
+	next      int
+}
+
+// setEndpoints replaces the endpoints of the pool, all initially healthy.
+func (p *endpointPool) setEndpoints(baseURLs []string) {
+	p.endpoints = nil
+	for _, u := range baseURLs {
+		p.endpoints = append(p.endpoints, &endpoint{baseURL: u, healthy: true})
+	}
+}
+
+// order returns the endpoints a request tries in turn: the healthy ones first, then the
+// unhealthy ones as a last resort. Health checks of unhealthy endpoints are started as due.
+func (p *endpointPool) order(ctx context.Context) []*endpoint {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	n := len(p.endpoints)
+	if n == 1 {
+		return p.endpoints
+	}
+
+	start := 0
+	if p.roundRobin {
+		start = p.next % n
+		p.next++
+	}
+
+	healthy := make([]*endpoint, 0, n)
+	var unhealthy []*endpoint
+	for i := 0; i < n; i++ {
+		e := p.endpoints[(start+i)%n]
+		if !e.healthy {
+			p.checkHealth(ctx, e)
+		}
+		if e.healthy {
+			healthy = append(healthy, e)
+		} else {
+			unhealthy = append(unhealthy, e)
+		}
+	}
+
+	return append(healthy, unhealthy...)
+}
+
+// report records the outcome of a request sent to e, and returns whether the endpoint
+// failed so that the request should fail over to the next endpoint.
+func (p *endpointPool) report(ctx context.Context, e *endpoint, resp *http.Response, err error) bool {
+	if len(p.endpoints) == 1 || ctx.Err() != nil {
+		return false
+	}
+
+	failed := err != nil
+	if resp != nil {
+		switch resp.StatusCode {
+		case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			failed = true
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	switch {
+	case failed && e.healthy:
+		e.healthy = false
+		e.checkAfter = time.Now().Add(p.interval)
+		fields := map[string]any{"endpoint": e.baseURL}
+		if err != nil {
+			fields["error"] = err.Error()
+		} else {
+			fields["status"] = resp.StatusCode
+		}
+		tflog.Warn(ctx, "TaskLite endpoint unhealthy", fields)
+	case !failed && !e.healthy:
+		e.healthy = true
+		tflog.Info(ctx, "TaskLite endpoint healthy again", map[string]any{"endpoint": e.baseURL})
+	}
+
+	return failed
+}
+
+// checkHealth starts a health check of the unhealthy endpoint e when one is due. It must
+// be called with p.mu held.
+func (p *endpointPool) checkHealth(ctx context.Context, e *endpoint) {
+	if e.checking || time.Now().Before(e.checkAfter) {
+		return
+	}
+
+	if p.healthCheckPath == "" {
+		e.healthy = true
+		return
+	}
+
+	e.checking = true
+	go func() {
+		err := p.probe(ctx, e.baseURL)
+
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		e.checking = false
+		if err != nil {
+			e.checkAfter = time.Now().Add(p.interval)
+			tflog.Debug(ctx, "TaskLite endpoint health check failed", map[string]any{"endpoint": e.baseURL, "error": err.Error()})
+			return
+		}
+		e.healthy = true
+		tflog.Info(ctx, "TaskLite endpoint healthy again", map[string]any{"endpoint": e.baseURL})
+	}()
+}
+
+// probe requests the health check path of baseURL.
+func (p *endpointPool) probe(ctx context.Context, baseURL string) error {
+	// the check outlives the request that started it
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), healthCheckTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+p.healthCheckPath, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer drainAndClose(resp)
+
+	if resp.StatusCode == http.StatusNotFound && p.healthCheckPath == DefaultHealthCheckPath {
+		// the server answered, but does not support version discovery
+		return nil
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("health check returned %s", resp.Status)
+	}
+	return nil
+}
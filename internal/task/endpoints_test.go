@@ -0,0 +1,143 @@
+package task
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFailoverToNextEndpoint(t *testing.T) {
+	primary, _, primaryCalls := setupStatusServer(http.StatusServiceUnavailable)
+	defer primary.Close()
+	secondary, _, secondaryCalls := setupStatusServer(http.StatusOK)
+	defer secondary.Close()
+	client := NewClient(primary.URL, WithRetryPolicy(RetryPolicy{}), WithFailoverEndpoints(secondary.URL))
+
+	for i := 0; i < 3; i++ {
+		task, err := client.ReadTask(context.Background(), 1)
+		assert.NoError(t, err)
+		assert.Equal(t, &Task{ID: 1, Title: "Test Task"}, task)
+	}
+
+	// the unhealthy primary endpoint is skipped after the first failure
+	assert.Equal(t, int32(1), primaryCalls.Load())
+	assert.Equal(t, int32(3), secondaryCalls.Load())
+}
+
+func TestFailoverUnreachableEndpoint(t *testing.T) {
+	unreachable := httptest.NewServer(http.NotFoundHandler())
+	unreachable.Close()
+	server, _, calls := setupStatusServer(http.StatusOK)
+	defer server.Close()
+	client := supportingIdempotencyKeys(NewClient(unreachable.URL, WithRetryPolicy(RetryPolicy{}), WithFailoverEndpoints(server.URL)))
+
+	_, err := client.CreateTask(context.Background(), Task{Title: "Test Task"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestFailoverSkipsNonIdempotentRequest(t *testing.T) {
+	primary, _, _ := setupStatusServer(http.StatusServiceUnavailable)
+	defer primary.Close()
+	secondary, _, secondaryCalls := setupStatusServer(http.StatusOK)
+	defer secondary.Close()
+	client := NewClient(primary.URL, WithRetryPolicy(RetryPolicy{}), WithFailoverEndpoints(secondary.URL))
+
+	resp, err := client.doRequest(context.Background(), http.MethodPost, TASK_URI, Task{Title: "Test Task"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, int32(0), secondaryCalls.Load())
+}
+
+func TestFailoverAllEndpointsFail(t *testing.T) {
+	primary, _, primaryCalls := setupStatusServer(http.StatusBadGateway)
+	defer primary.Close()
+	secondary, _, secondaryCalls := setupStatusServer(http.StatusServiceUnavailable)
+	defer secondary.Close()
+	client := NewClient(primary.URL, WithRetryPolicy(testRetryPolicy), WithFailoverEndpoints(secondary.URL), WithCircuitBreaker(0, 0))
+
+	_, err := client.ReadTask(context.Background(), 1)
+
+	// every attempt tries all endpoints, the unhealthy ones last
+	assert.ErrorContains(t, err, "HTTP 503")
+	assert.Equal(t, int32(4), primaryCalls.Load())
+	assert.Equal(t, int32(4), secondaryCalls.Load())
+}
+
+func TestRoundRobin(t *testing.T) {
+	first, _, firstCalls := setupStatusServer(http.StatusOK)
+	defer first.Close()
+	second, _, secondCalls := setupStatusServer(http.StatusOK)
+	defer second.Close()
+	client := NewClient(first.URL, WithFailoverEndpoints(second.URL), WithRoundRobin())
+
+	for i := 0; i < 4; i++ {
+		_, err := client.ReadTask(context.Background(), 1)
+		assert.NoError(t, err)
+	}
+
+	assert.Equal(t, int32(2), firstCalls.Load())
+	assert.Equal(t, int32(2), secondCalls.Load())
+}
+
+func TestHealthCheckRestoresEndpoint(t *testing.T) {
+	primary, primaryStatus, _ := setupStatusServer(http.StatusServiceUnavailable)
+	defer primary.Close()
+	secondary, _, secondaryCalls := setupStatusServer(http.StatusOK)
+	defer secondary.Close()
+	client := NewClient(primary.URL, WithRetryPolicy(RetryPolicy{}), WithFailoverEndpoints(secondary.URL))
+	client.endpoints.interval = 10 * time.Millisecond
+
+	_, err := client.ReadTask(context.Background(), 1)
+	assert.NoError(t, err)
+
+	primaryStatus.Store(http.StatusOK)
+	time.Sleep(20 * time.Millisecond)
+
+	// a request starts the health check, requests after it succeeded go to the primary endpoint
+	assert.Eventually(t, func() bool {
+		_, err := client.ReadTask(context.Background(), 1)
+		assert.NoError(t, err)
+		calls := secondaryCalls.Load()
+		_, err = client.ReadTask(context.Background(), 1)
+		assert.NoError(t, err)
+		return secondaryCalls.Load() == calls
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestHealthCheckPath(t *testing.T) {
+	tests := map[string]struct {
+		options []Option
+		status  int
+		path    string
+		healthy bool
+	}{
+		"default":               {status: http.StatusOK, path: VERSION_URI, healthy: true},
+		"default legacy server": {status: http.StatusNotFound, path: VERSION_URI, healthy: true},
+		"default unavailable":   {status: http.StatusServiceUnavailable, path: VERSION_URI, healthy: false},
+		"custom":                {options: []Option{WithHealthCheckPath("/healthz")}, status: http.StatusOK, path: "/healthz", healthy: true},
+		"custom not found":      {options: []Option{WithHealthCheckPath("/healthz")}, status: http.StatusNotFound, path: "/healthz", healthy: false},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var path string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				path = r.URL.Path
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+			client := NewClient(server.URL, tt.options...)
+
+			err := client.endpoints.probe(context.Background(), server.URL)
+
+			assert.Equal(t, tt.path, path)
+			assert.Equal(t, tt.healthy, err == nil)
+		})
+	}
+}
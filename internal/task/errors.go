@@ -0,0 +1,127 @@
+package task
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// ErrNotFound is matched by errors.Is for APIErrors with status 404.
+var ErrNotFound = errors.New("task not found")
+
+// ErrPreconditionFailed is matched by errors.Is for APIErrors with status 412, returned
+// when the task changed since the ETag sent in If-Match was read.
+var ErrPreconditionFailed = errors.New("task changed since it was read")
+
+// RequestIDHeader is the response header carrying the server-side request identifier.
+const RequestIDHeader = "X-Request-Id"
+
+// APIError describes a non-2xx response returned by the TaskLite API.
+type APIError struct {
+	StatusCode int
+	Method     string
+	URL        string
+	RequestID  string
+	// Body is the raw response body.
+	Body string
+	// Message and Field are parsed from a JSON error body when the server sends one.
+	Message string
+	Field   string
+}
+
+// apiErrorBody is the JSON error payload understood by newAPIError.
+type apiErrorBody struct {
+	Message string `json:"message"`
+	Error   string `json:"error"`
+	Field   string `json:"field"`
+}
+
+// newAPIError builds an APIError from resp, consuming its body.
+func newAPIError(resp *http.Response) *APIError {
+	body, _ := io.ReadAll(resp.Body)
+	e := &APIError{
+		StatusCode: resp.StatusCode,
+		RequestID:  resp.Header.Get(RequestIDHeader),
+		Body:       string(body),
+	}
+	if resp.Request != nil {
+		e.Method = resp.Request.Method
+		e.URL = resp.Request.URL.String()
+	}
+
+	var b apiErrorBody
+	if json.Unmarshal(body, &b) == nil {
+		e.Message = b.Message
+		if e.Message == "" {
+			e.Message = b.Error
+		}
+		e.Field = b.Field
+	}
+
+	return e
+}
+
+func (e *APIError) Error() string {
+	msg := e.Message
+	if msg == "" {
+		msg = strings.TrimSpace(e.Body)
+	}
+	s := fmt.Sprintf("HTTP %d: %s", e.StatusCode, msg)
+	if e.Method != "" {
+		s = fmt.Sprintf("%s %s: %s", e.Method, e.URL, s)
+	}
+	if e.RequestID != "" {
+		s = fmt.Sprintf("%s (request ID %s)", s, e.RequestID)
+	}
+	return s
+}
+
+// Is reports whether a 404 APIError matches ErrNotFound, and a 412 APIError matches
+// ErrPreconditionFailed.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrPreconditionFailed:
+		return e.StatusCode == http.StatusPreconditionFailed
+	}
+	return false
+}
+
+// IsNotFound reports whether err is an APIError with status 404.
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsConflict reports whether err is an APIError with status 409.
+func IsConflict(err error) bool {
+	return hasStatus(err, http.StatusConflict)
+}
+
+// IsRetryable reports whether err is an APIError with a status worth retrying.
+func IsRetryable(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && isRetryableStatus(apiErr.StatusCode)
+}
+
+// isRetryableStatus reports whether a response with the given status is a transient failure.
+func isRetryableStatus(status int) bool {
+	switch status {
+	case http.StatusRequestTimeout,
+		http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+func hasStatus(err error, status int) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == status
+}
@@ -0,0 +1,52 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(RequestIDHeader, "req-123")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = io.WriteString(w, `{"message":"title must not be empty","field":"title"}`)
+	}))
+	defer server.Close()
+
+	_, err := (NewClient(server.URL)).CreateTask(context.Background(), Task{})
+
+	var apiErr *APIError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, &APIError{
+		StatusCode: http.StatusUnprocessableEntity,
+		Method:     http.MethodPost,
+		URL:        server.URL + TASK_URI,
+		RequestID:  "req-123",
+		Body:       `{"message":"title must not be empty","field":"title"}`,
+		Message:    "title must not be empty",
+		Field:      "title",
+	}, apiErr)
+	assert.EqualError(t, err, fmt.Sprintf("POST %s%s: HTTP 422: title must not be empty (request ID req-123)", server.URL, TASK_URI))
+}
+
+func TestAPIErrorHelpers(t *testing.T) {
+	notFound := fmt.Errorf("wrapped: %w", &APIError{StatusCode: http.StatusNotFound})
+	conflict := &APIError{StatusCode: http.StatusConflict}
+	unavailable := &APIError{StatusCode: http.StatusServiceUnavailable}
+
+	assert.True(t, IsNotFound(notFound))
+	assert.True(t, errors.Is(notFound, ErrNotFound))
+	assert.False(t, IsNotFound(conflict))
+	assert.True(t, IsConflict(conflict))
+	assert.False(t, errors.Is(conflict, ErrNotFound))
+	assert.True(t, IsRetryable(unavailable))
+	assert.False(t, IsRetryable(conflict))
+	assert.False(t, IsRetryable(errors.New("HTTP 503")))
+}
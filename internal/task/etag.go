@@ -0,0 +1,33 @@
+package task
+
+import (
+	"context"
+	"net/http"
+)
+
+const (
+	// ETagHeader carries the version of a task in responses.
+	ETagHeader = "ETag"
+	// IfMatchHeader makes an update or delete fail with 412 when the task changed.
+	IfMatchHeader = "If-Match"
+)
+
+type ifMatchContextKey struct{}
+
+// ContextWithIfMatch returns a context making UpdateTask and DeleteTask send etag in the
+// If-Match header, so they fail with ErrPreconditionFailed when the task was changed
+// since etag was read. An empty etag sends no header.
+func ContextWithIfMatch(ctx context.Context, etag string) context.Context {
+	return context.WithValue(ctx, ifMatchContextKey{}, etag)
+}
+
+// ifMatchFromContext returns the ETag set by ContextWithIfMatch, if any.
+func ifMatchFromContext(ctx context.Context) string {
+	etag, _ := ctx.Value(ifMatchContextKey{}).(string)
+	return etag
+}
+
+// isConditional reports whether a request with method is sent with If-Match.
+func isConditional(method string) bool {
+	return method == http.MethodPut || method == http.MethodPatch || method == http.MethodDelete
+}
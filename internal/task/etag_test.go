@@ -0,0 +1,90 @@
+package task
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// setupVersionedServer serves a single task whose ETag changes on every update, honouring
+// If-Match on updates and deletes.
+func setupVersionedServer(t *testing.T) *httptest.Server {
+	var mu sync.Mutex
+	version := 1
+	task := Task{ID: 1, Title: "Test Task"}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		etag := fmt.Sprintf(`"v%d"`, version)
+		if ifMatch := r.Header.Get(IfMatchHeader); ifMatch != "" && ifMatch != etag {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPut:
+			if err := json.NewDecoder(r.Body).Decode(&task); err != nil {
+				t.Fatal(err)
+			}
+			version++
+			etag = fmt.Sprintf(`"v%d"`, version)
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.Header().Set(ETagHeader, etag)
+		_ = json.NewEncoder(w).Encode(task)
+	}))
+}
+
+func TestReadTaskETag(t *testing.T) {
+	server := setupVersionedServer(t)
+	defer server.Close()
+
+	task, err := NewClient(server.URL).ReadTask(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &Task{ID: 1, Title: "Test Task", ETag: `"v1"`}, task)
+}
+
+func TestUpdateTaskIfMatch(t *testing.T) {
+	server := setupVersionedServer(t)
+	defer server.Close()
+	client := NewClient(server.URL)
+
+	task, err := client.UpdateTask(ContextWithIfMatch(context.Background(), `"v1"`), Task{ID: 1, Title: "Updated Task"})
+	assert.NoError(t, err)
+	assert.Equal(t, &Task{ID: 1, Title: "Updated Task", ETag: `"v2"`}, task)
+
+	_, err = client.UpdateTask(ContextWithIfMatch(context.Background(), `"v1"`), Task{ID: 1, Title: "Stale Task"})
+	assert.ErrorIs(t, err, ErrPreconditionFailed)
+	assert.NotErrorIs(t, err, ErrNotFound)
+}
+
+func TestDeleteTaskIfMatch(t *testing.T) {
+	server := setupVersionedServer(t)
+	defer server.Close()
+	client := NewClient(server.URL)
+
+	err := client.DeleteTask(ContextWithIfMatch(context.Background(), `"v0"`), 1)
+	assert.ErrorIs(t, err, ErrPreconditionFailed)
+
+	err = client.DeleteTask(ContextWithIfMatch(context.Background(), `"v1"`), 1)
+	assert.NoError(t, err)
+}
+
+func TestIfMatchNotSentWithoutETag(t *testing.T) {
+	server := setupVersionedServer(t)
+	defer server.Close()
+	client := NewClient(server.URL)
+
+	_, err := client.UpdateTask(ContextWithIfMatch(context.Background(), ""), Task{ID: 1, Title: "Updated Task"})
+	assert.NoError(t, err)
+	assert.NoError(t, client.DeleteTask(context.Background(), 1))
+}
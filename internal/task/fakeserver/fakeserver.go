@@ -0,0 +1,394 @@
+// Package fakeserver implements a fake TaskLite API, for tests and local development.
+//
+// The fake keeps its tasks in memory and implements the whole task API: listing,
+// creating with auto-incremented IDs, reading, replacing, patching and deleting tasks,
+// version discovery, ETags with If-Match and Idempotency-Key replays. Faults, such as
+// errors, dropped connections and latency, are injected with API.Inject.
+package fakeserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"terraform-provider-tasklite/internal/task"
+)
+
+// DefaultServerInfo is the server information served by a new API, advertising every
+// capability.
+var DefaultServerInfo = task.ServerInfo{
+	Version:      "1.0.0",
+	Capabilities: []task.Capability{task.CapabilityIdempotencyKeys, task.CapabilityETags, task.CapabilityPatch},
+}
+
+// Request is a request received by the API.
+type Request struct {
+	Method string
+	Path   string
+	Header http.Header
+	Body   string
+}
+
+// storedTask is a task with the version its ETag is derived from.
+type storedTask struct {
+	task    task.Task
+	version int
+}
+
+// storedResponse is the response to a create request, replayed for requests with the same
+// Idempotency-Key.
+type storedResponse struct {
+	etag string
+	body []byte
+}
+
+// API is an in-memory TaskLite API. It is safe for concurrent use.
+type API struct {
+	mu        sync.Mutex
+	info      *task.ServerInfo
+	tasks     map[int32]*storedTask
+	lastID    int32
+	responses map[string]storedResponse
+	faults    []Fault
+	requests  []Request
+	hooks     []func(Request)
+}
+
+// NewAPI returns an API without tasks, advertising DefaultServerInfo.
+func NewAPI() *API {
+	info := DefaultServerInfo
+	return &API{
+		info:      &info,
+		tasks:     map[int32]*storedTask{},
+		responses: map[string]storedResponse{},
+	}
+}
+
+// SetServerInfo sets the version and capabilities served by the API. Capabilities that
+// are not advertised are not implemented either: ETags and Idempotency-Key headers are
+// ignored, and PATCH is rejected. A nil info makes the API behave like a server
+// predating version discovery, such as TechChallengeApp, which serves no version and
+// implements none of the capabilities.
+func (a *API) SetServerInfo(info *task.ServerInfo) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.info = info
+}
+
+// AddTask stores t under the next ID, as if it was created through the API, and returns
+// the stored task.
+func (a *API) AddTask(t task.Task) task.Task {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.lastID++
+	t.ID = a.lastID
+	a.tasks[t.ID] = &storedTask{task: t, version: 1}
+	return a.taskLocked(t.ID)
+}
+
+// Task returns the task with the given id.
+func (a *API) Task(id int32) (task.Task, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if _, ok := a.tasks[id]; !ok {
+		return task.Task{}, false
+	}
+	return a.taskLocked(id), true
+}
+
+// Tasks returns all tasks, ordered by ID.
+func (a *API) Tasks() []task.Task {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.tasksLocked()
+}
+
+// EditTask changes the task with the given id, as someone else would, giving it a new
+// ETag even when edit changes nothing. It reports whether the task exists.
+func (a *API) EditTask(id int32, edit func(*task.Task)) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	s, ok := a.tasks[id]
+	if !ok {
+		return false
+	}
+	edit(&s.task)
+	s.task.ID = id
+	s.version++
+	return true
+}
+
+// RemoveTask deletes the task with the given id, as someone else would. It reports
+// whether the task existed.
+func (a *API) RemoveTask(id int32) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	_, ok := a.tasks[id]
+	delete(a.tasks, id)
+	return ok
+}
+
+// Inject adds faults to the requests handled from now on. The first fault injected sees
+// the requests first.
+func (a *API) Inject(faults ...Fault) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.faults = append(a.faults, faults...)
+}
+
+// ClearFaults removes the injected faults.
+func (a *API) ClearFaults() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.faults = nil
+}
+
+// OnRequest calls hook with every request received from now on, before faults are
+// applied, e.g. to assert on the requests sent by a client.
+func (a *API) OnRequest(hook func(Request)) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.hooks = append(a.hooks, hook)
+}
+
+// Requests returns the requests received so far, including those failed by faults.
+func (a *API) Requests() []Request {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return slices.Clone(a.requests)
+}
+
+// ServeHTTP records the request, then serves it through the injected faults.
+func (a *API) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "", "failed to read the request body")
+		return
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	req := Request{Method: r.Method, Path: r.URL.Path, Header: r.Header.Clone(), Body: string(body)}
+
+	a.mu.Lock()
+	a.requests = append(a.requests, req)
+	hooks := slices.Clone(a.hooks)
+	faults := slices.Clone(a.faults)
+	a.mu.Unlock()
+
+	for _, hook := range hooks {
+		hook(req)
+	}
+
+	var h http.Handler = http.HandlerFunc(a.serve)
+	for i := len(faults) - 1; i >= 0; i-- {
+		h = faults[i](h)
+	}
+	h.ServeHTTP(w, r)
+}
+
+// serve implements the TaskLite API.
+func (a *API) serve(w http.ResponseWriter, r *http.Request) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if r.URL.Path == task.VERSION_URI {
+		if a.info == nil {
+			writeError(w, http.StatusNotFound, "", "not found")
+			return
+		}
+		if r.Method != http.MethodGet {
+			methodNotAllowed(w, http.MethodGet)
+			return
+		}
+		writeJSON(w, http.StatusOK, a.info)
+		return
+	}
+
+	if r.URL.Path == task.TASK_URI {
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, a.tasksLocked())
+		case http.MethodPost:
+			a.create(w, r)
+		default:
+			methodNotAllowed(w, http.MethodGet, http.MethodPost)
+		}
+		return
+	}
+
+	rest, ok := strings.CutPrefix(r.URL.Path, task.TASK_URI)
+	if !ok {
+		writeError(w, http.StatusNotFound, "", "not found")
+		return
+	}
+	id, err := strconv.ParseInt(strings.TrimSuffix(rest, "/"), 10, 32)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "id", fmt.Sprintf("invalid task id %q", strings.TrimSuffix(rest, "/")))
+		return
+	}
+	s, ok := a.tasks[int32(id)]
+	if !ok {
+		writeError(w, http.StatusNotFound, "", "task not found")
+		return
+	}
+	if a.supports(task.CapabilityETags) {
+		if ifMatch := r.Header.Get(task.IfMatchHeader); ifMatch != "" && ifMatch != "*" && ifMatch != etag(s) {
+			writeError(w, http.StatusPreconditionFailed, "", "task changed since it was read")
+			return
+		}
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		a.writeTask(w, http.StatusOK, s)
+	case http.MethodPut:
+		t := task.Task{}
+		if !decodeTask(w, r, &t) {
+			return
+		}
+		t.ID = s.task.ID
+		s.task = t
+		s.version++
+		a.writeTask(w, http.StatusOK, s)
+	case http.MethodPatch:
+		if !a.supports(task.CapabilityPatch) {
+			methodNotAllowed(w, http.MethodGet, http.MethodPut, http.MethodDelete)
+			return
+		}
+		if r.Header.Get("Content-Type") != task.MergePatchContentType {
+			writeError(w, http.StatusUnsupportedMediaType, "", "expected a "+task.MergePatchContentType+" body")
+			return
+		}
+		// unmarshalling into the stored fields leaves those missing from the patch as they are
+		t := s.task
+		if !decodeTask(w, r, &t) {
+			return
+		}
+		t.ID = s.task.ID
+		s.task = t
+		s.version++
+		a.writeTask(w, http.StatusOK, s)
+	case http.MethodDelete:
+		delete(a.tasks, s.task.ID)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		methodNotAllowed(w, http.MethodGet, http.MethodPut, http.MethodPatch, http.MethodDelete)
+	}
+}
+
+// create stores a new task, or replays the response of an earlier request with the same
+// Idempotency-Key.
+func (a *API) create(w http.ResponseWriter, r *http.Request) {
+	key := r.Header.Get(task.IdempotencyKeyHeader)
+	if !a.supports(task.CapabilityIdempotencyKeys) {
+		key = ""
+	}
+	if resp, ok := a.responses[key]; ok && key != "" {
+		w.Header().Set(task.IdempotentReplayedHeader, "true")
+		if resp.etag != "" {
+			w.Header().Set(task.ETagHeader, resp.etag)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write(resp.body)
+		return
+	}
+
+	t := task.Task{}
+	if !decodeTask(w, r, &t) {
+		return
+	}
+	a.lastID++
+	t.ID = a.lastID
+	s := &storedTask{task: t, version: 1}
+	a.tasks[t.ID] = s
+
+	body, _ := json.Marshal(s.task)
+	if key != "" {
+		a.responses[key] = storedResponse{etag: a.etag(s), body: body}
+	}
+	a.writeTask(w, http.StatusCreated, s)
+}
+
+// supports reports whether the API implements capability.
+func (a *API) supports(capability task.Capability) bool {
+	return a.info != nil && slices.Contains(a.info.Capabilities, capability)
+}
+
+// etag returns the ETag of s, or an empty string when ETags are not supported.
+func (a *API) etag(s *storedTask) string {
+	if !a.supports(task.CapabilityETags) {
+		return ""
+	}
+	return etag(s)
+}
+
+func (a *API) writeTask(w http.ResponseWriter, status int, s *storedTask) {
+	if tag := a.etag(s); tag != "" {
+		w.Header().Set(task.ETagHeader, tag)
+	}
+	writeJSON(w, status, s.task)
+}
+
+func (a *API) taskLocked(id int32) task.Task {
+	s := a.tasks[id]
+	t := s.task
+	t.ETag = a.etag(s)
+	return t
+}
+
+func (a *API) tasksLocked() []task.Task {
+	tt := make([]task.Task, 0, len(a.tasks))
+	for id := range a.tasks {
+		tt = append(tt, a.taskLocked(id))
+	}
+	slices.SortFunc(tt, func(x, y task.Task) int { return int(x.ID) - int(y.ID) })
+	return tt
+}
+
+func etag(s *storedTask) string {
+	return fmt.Sprintf(`"%d-%d"`, s.task.ID, s.version)
+}
+
+// decodeTask decodes the request body into t and validates the result, writing an error
+// response when it fails.
+func decodeTask(w http.ResponseWriter, r *http.Request, t *task.Task) bool {
+	if err := json.NewDecoder(r.Body).Decode(t); err != nil {
+		writeError(w, http.StatusBadRequest, "", "invalid JSON body: "+err.Error())
+		return false
+	}
+	switch {
+	case strings.TrimSpace(t.Title) == "":
+		writeError(w, http.StatusUnprocessableEntity, "title", "title must not be empty")
+		return false
+	case t.Priority < 0:
+		writeError(w, http.StatusUnprocessableEntity, "priority", "priority must not be negative")
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes the JSON error body understood by the task client.
+func writeError(w http.ResponseWriter, status int, field, message string) {
+	body := map[string]string{"message": message}
+	if field != "" {
+		body["field"] = field
+	}
+	writeJSON(w, status, body)
+}
+
+func methodNotAllowed(w http.ResponseWriter, allowed ...string) {
+	w.Header().Set("Allow", strings.Join(allowed, ", "))
+	writeError(w, http.StatusMethodNotAllowed, "", "method not allowed")
+}
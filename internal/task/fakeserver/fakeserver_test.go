@@ -0,0 +1,198 @@
+package fakeserver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"terraform-provider-tasklite/internal/task"
+)
+
+// server is an API served over HTTP for the duration of a test.
+type server struct {
+	*API
+	*httptest.Server
+}
+
+func newServer(t *testing.T) server {
+	t.Helper()
+	api := NewAPI()
+	s := server{API: api, Server: httptest.NewServer(api)}
+	t.Cleanup(s.Close)
+	return s
+}
+
+func TestServerTaskLifecycle(t *testing.T) {
+	server := newServer(t)
+	client := task.NewClient(server.URL)
+	ctx := context.Background()
+
+	first, err := client.CreateTask(ctx, task.Task{Title: "First task", Priority: 2})
+	assert.NoError(t, err)
+	second, err := client.CreateTask(ctx, task.Task{Title: "Second task"})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), first.ID)
+	assert.Equal(t, int32(2), second.ID)
+	assert.NotEmpty(t, first.ETag)
+
+	read, err := client.ReadTask(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, first, read)
+
+	updated, err := client.UpdateTask(ctx, task.Task{ID: 1, Title: "Updated task", Complete: true})
+	assert.NoError(t, err)
+	assert.NotEqual(t, first.ETag, updated.ETag)
+	updated.ETag = ""
+	assert.Equal(t, &task.Task{ID: 1, Title: "Updated task", Complete: true}, updated)
+
+	patched, err := client.PatchTask(ctx, 1, map[string]any{"priority": 5})
+	assert.NoError(t, err)
+	assert.Equal(t, "Updated task", patched.Title)
+	assert.Equal(t, int32(5), patched.Priority)
+
+	assert.NoError(t, client.DeleteTask(ctx, 2))
+	_, err = client.ReadTask(ctx, 2)
+	assert.ErrorIs(t, err, task.ErrNotFound)
+	assert.ErrorIs(t, client.DeleteTask(ctx, 2), task.ErrNotFound)
+
+	tasks, err := client.ListTasks(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []task.Task{{ID: 1, Title: "Updated task", Complete: true, Priority: 5}}, tasks)
+	assert.Len(t, server.Tasks(), 1)
+}
+
+func TestServerListEmpty(t *testing.T) {
+	server := newServer(t)
+
+	tasks, err := task.NewClient(server.URL).ListTasks(context.Background())
+
+	assert.NoError(t, err)
+	assert.Empty(t, tasks)
+	assert.NotNil(t, tasks)
+}
+
+func TestServerValidation(t *testing.T) {
+	server := newServer(t)
+	client := task.NewClient(server.URL)
+	existing := server.AddTask(task.Task{Title: "Existing task"})
+
+	_, err := client.CreateTask(context.Background(), task.Task{Title: "  "})
+	var apiErr *task.APIError
+	assert.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusUnprocessableEntity, apiErr.StatusCode)
+	assert.Equal(t, "title", apiErr.Field)
+
+	_, err = client.UpdateTask(context.Background(), task.Task{ID: existing.ID, Title: "Task", Priority: -1})
+	assert.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "priority", apiErr.Field)
+
+	assert.Equal(t, []task.Task{existing}, server.Tasks())
+}
+
+func TestServerIfMatch(t *testing.T) {
+	server := newServer(t)
+	client := task.NewClient(server.URL)
+	existing := server.AddTask(task.Task{Title: "Shared task"})
+	assert.True(t, server.EditTask(existing.ID, func(t *task.Task) { t.Complete = true }))
+
+	_, err := client.UpdateTask(task.ContextWithIfMatch(context.Background(), existing.ETag), task.Task{ID: existing.ID, Title: "Stale task"})
+	assert.ErrorIs(t, err, task.ErrPreconditionFailed)
+	err = client.DeleteTask(task.ContextWithIfMatch(context.Background(), existing.ETag), existing.ID)
+	assert.ErrorIs(t, err, task.ErrPreconditionFailed)
+
+	current, ok := server.Task(existing.ID)
+	assert.True(t, ok)
+	assert.True(t, current.Complete)
+	assert.NoError(t, client.DeleteTask(task.ContextWithIfMatch(context.Background(), current.ETag), existing.ID))
+}
+
+func TestServerIdempotencyKey(t *testing.T) {
+	server := newServer(t)
+	client := task.NewClient(server.URL)
+	_, err := client.Probe(context.Background())
+	assert.NoError(t, err)
+	ctx := task.ContextWithIdempotencyKey(context.Background(), "key")
+
+	first, err := client.CreateTask(ctx, task.Task{Title: "Task"})
+	assert.NoError(t, err)
+	replayed, err := client.CreateTask(ctx, task.Task{Title: "Task"})
+	assert.NoError(t, err)
+
+	assert.Equal(t, first, replayed)
+	assert.Len(t, server.Tasks(), 1)
+}
+
+func TestServerInfo(t *testing.T) {
+	server := newServer(t)
+	client := task.NewClient(server.URL)
+
+	info, err := client.Probe(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, &DefaultServerInfo, info)
+
+	server.SetServerInfo(&task.ServerInfo{Version: "0.9.0"})
+	created, err := client.CreateTask(context.Background(), task.Task{Title: "Task"})
+	assert.NoError(t, err)
+	assert.Empty(t, created.ETag)
+	_, err = client.PatchTask(context.Background(), created.ID, map[string]any{"complete": true})
+	assert.ErrorContains(t, err, "HTTP 405")
+
+	server.SetServerInfo(nil)
+	info, err = client.Probe(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, info.Legacy())
+	// legacy servers implement none of the capabilities
+	_, err = client.PatchTask(context.Background(), created.ID, map[string]any{"complete": true})
+	assert.ErrorContains(t, err, "HTTP 405")
+}
+
+func TestServerRequests(t *testing.T) {
+	server := newServer(t)
+	var mu sync.Mutex
+	var keys []string
+	server.OnRequest(func(r Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		keys = append(keys, r.Header.Get(task.IdempotencyKeyHeader))
+	})
+
+	client := task.NewClient(server.URL)
+	_, err := client.Probe(context.Background())
+	assert.NoError(t, err)
+	_, err = client.CreateTask(context.Background(), task.Task{Title: "Task"})
+	assert.NoError(t, err)
+
+	requests := server.Requests()
+	assert.Len(t, requests, 2)
+	assert.Equal(t, http.MethodPost, requests[1].Method)
+	assert.Equal(t, task.TASK_URI, requests[1].Path)
+	assert.JSONEq(t, `{"title":"Task","complete":false,"priority":0}`, requests[1].Body)
+	assert.Len(t, keys, 2)
+	assert.NotEmpty(t, keys[1])
+}
+
+func TestServerConcurrentCreates(t *testing.T) {
+	server := newServer(t)
+	client := task.NewClient(server.URL)
+
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.CreateTask(context.Background(), task.Task{Title: "Task"})
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	tasks := server.Tasks()
+	assert.Len(t, tasks, 20)
+	for i, tt := range tasks {
+		assert.Equal(t, int32(i+1), tt.ID)
+	}
+}
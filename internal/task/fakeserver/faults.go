@@ -0,0 +1,97 @@
+package fakeserver
+
+import (
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+// Fault changes how the API handles requests. It wraps next, the handler serving the
+// request as the API would.
+type Fault func(next http.Handler) http.Handler
+
+// Fail answers requests with status and a JSON error body, without handling them.
+func Fail(status int) Fault {
+	return func(http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			writeError(w, status, "", http.StatusText(status))
+		})
+	}
+}
+
+// DropConnection closes the connection of requests without handling them.
+func DropConnection() Fault {
+	return func(http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			closeConnection(w)
+		})
+	}
+}
+
+// LoseResponse handles requests, then closes their connection instead of responding, as if
+// the response was lost on its way to the client.
+func LoseResponse() Fault {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(discardResponse{header: http.Header{}}, r)
+			closeConnection(w)
+		})
+	}
+}
+
+// Delay waits for d, or until the client gives up, before handling requests.
+func Delay(d time.Duration) Fault {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-time.After(d):
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// Times applies f to the next n requests reaching it only.
+func Times(n int, f Fault) Fault {
+	var remaining atomic.Int64
+	remaining.Store(int64(n))
+	return When(func(*http.Request) bool { return remaining.Add(-1) >= 0 }, f)
+}
+
+// OnMethod applies f to the requests with the given method only.
+func OnMethod(method string, f Fault) Fault {
+	return When(func(r *http.Request) bool { return r.Method == method }, f)
+}
+
+// When applies f to the requests matched by match only.
+func When(match func(*http.Request) bool, f Fault) Fault {
+	return func(next http.Handler) http.Handler {
+		faulty := f(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if match(r) {
+				faulty.ServeHTTP(w, r)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func closeConnection(w http.ResponseWriter) {
+	conn, _, err := http.NewResponseController(w).Hijack()
+	if err != nil {
+		panic(http.ErrAbortHandler)
+	}
+	_ = conn.Close()
+}
+
+// discardResponse is a response writer dropping the response.
+type discardResponse struct {
+	header http.Header
+}
+
+func (d discardResponse) Header() http.Header         { return d.header }
+func (d discardResponse) Write(b []byte) (int, error) { return len(b), nil }
+func (d discardResponse) WriteHeader(int)             {}
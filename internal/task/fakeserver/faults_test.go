@@ -0,0 +1,88 @@
+package fakeserver
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"terraform-provider-tasklite/internal/task"
+)
+
+// noRetries makes clients send every request once.
+var noRetries = task.WithRetryPolicy(task.RetryPolicy{})
+
+func TestFail(t *testing.T) {
+	server := newServer(t)
+	server.Inject(Times(1, Fail(http.StatusServiceUnavailable)))
+	client := task.NewClient(server.URL, noRetries)
+
+	_, err := client.ListTasks(context.Background())
+	var apiErr *task.APIError
+	assert.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusServiceUnavailable, apiErr.StatusCode)
+
+	_, err = client.ListTasks(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, server.Requests(), 2)
+}
+
+func TestOnMethod(t *testing.T) {
+	server := newServer(t)
+	server.Inject(OnMethod(http.MethodPatch, Fail(http.StatusNotImplemented)))
+	client := task.NewClient(server.URL, noRetries)
+
+	created, err := client.CreateTask(context.Background(), task.Task{Title: "Task"})
+	assert.NoError(t, err)
+	_, err = client.PatchTask(context.Background(), created.ID, map[string]any{"complete": true})
+	assert.ErrorContains(t, err, "HTTP 501")
+
+	server.ClearFaults()
+	_, err = client.PatchTask(context.Background(), created.ID, map[string]any{"complete": true})
+	assert.NoError(t, err)
+}
+
+func TestDropConnection(t *testing.T) {
+	server := newServer(t)
+	server.Inject(Times(1, DropConnection()))
+	client := task.NewClient(server.URL, noRetries)
+
+	_, err := client.CreateTask(context.Background(), task.Task{Title: "Task"})
+
+	assert.Error(t, err)
+	assert.Empty(t, server.Tasks())
+}
+
+func TestLoseResponse(t *testing.T) {
+	server := newServer(t)
+	// net/http transparently resends requests with an Idempotency-Key on reused connections
+	server.Config.SetKeepAlivesEnabled(false)
+	client := task.NewClient(server.URL, noRetries)
+	_, err := client.Probe(context.Background())
+	assert.NoError(t, err)
+	server.Inject(Times(1, LoseResponse()))
+	ctx := task.ContextWithIdempotencyKey(context.Background(), "key")
+
+	_, err = client.CreateTask(ctx, task.Task{Title: "Task"})
+	assert.Error(t, err)
+	assert.Len(t, server.Tasks(), 1)
+
+	created, err := client.CreateTask(ctx, task.Task{Title: "Task"})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), created.ID)
+	assert.Len(t, server.Tasks(), 1)
+}
+
+func TestDelay(t *testing.T) {
+	server := newServer(t)
+	server.Inject(Delay(time.Second))
+	client := task.NewClient(server.URL, noRetries, task.WithRequestTimeout(50*time.Millisecond))
+
+	_, err := client.ListTasks(context.Background())
+
+	var timeoutErr *task.TimeoutError
+	assert.True(t, errors.As(err, &timeoutErr))
+}
@@ -0,0 +1,40 @@
+package fakeserver
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"terraform-provider-tasklite/internal/task"
+)
+
+func TestSaveLoad(t *testing.T) {
+	api := NewAPI()
+	api.AddTask(task.Task{Title: "First task", Priority: 2})
+	second := api.AddTask(task.Task{Title: "Second task", Complete: true})
+	api.AddTask(task.Task{Title: "Removed task"})
+	api.RemoveTask(3)
+
+	var buf bytes.Buffer
+	assert.NoError(t, api.Save(&buf))
+
+	restored := NewAPI()
+	assert.NoError(t, restored.Load(&buf))
+	assert.Equal(t, api.Tasks(), restored.Tasks())
+
+	// IDs are not reused, and ETags survive the restore
+	added := restored.AddTask(task.Task{Title: "Third task"})
+	assert.Equal(t, int32(4), added.ID)
+	restoredSecond, _ := restored.Task(second.ID)
+	assert.Equal(t, second.ETag, restoredSecond.ETag)
+}
+
+func TestLoadInvalid(t *testing.T) {
+	api := NewAPI()
+	api.AddTask(task.Task{Title: "Task"})
+
+	assert.Error(t, api.Load(strings.NewReader("not json")))
+	assert.Len(t, api.Tasks(), 1)
+}
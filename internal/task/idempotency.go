@@ -0,0 +1,35 @@
+package task
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-uuid"
+)
+
+const (
+	// IdempotencyKeyHeader marks a request as safe to retry regardless of its method.
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader is set by the server when it answers with the stored
+	// response of an earlier request carrying the same Idempotency-Key.
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+)
+
+type idempotencyKeyContextKey struct{}
+
+// NewIdempotencyKey returns a random key for the Idempotency-Key header.
+func NewIdempotencyKey() (string, error) {
+	return uuid.GenerateUUID()
+}
+
+// ContextWithIdempotencyKey returns a context making CreateTask send key as its
+// Idempotency-Key, so a create can be resumed later without creating a duplicate. The key
+// is only sent to servers known to support idempotency keys.
+func ContextWithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyContextKey{}, key)
+}
+
+// idempotencyKeyFromContext returns the key set by ContextWithIdempotencyKey, if any.
+func idempotencyKeyFromContext(ctx context.Context) string {
+	key, _ := ctx.Value(idempotencyKeyContextKey{}).(string)
+	return key
+}
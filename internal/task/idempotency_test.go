@@ -0,0 +1,107 @@
+package task
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// supportingIdempotencyKeys returns c, as if a probe found that the server supports
+// idempotency keys.
+func supportingIdempotencyKeys(c *Client) *Client {
+	c.serverInfo.Store(&ServerInfo{Version: "1.0.0", Capabilities: []Capability{CapabilityIdempotencyKeys}})
+	return c
+}
+
+func TestCreateTaskRetriesWithSameIdempotencyKey(t *testing.T) {
+	var mu sync.Mutex
+	var keys []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		keys = append(keys, r.Header.Get(IdempotencyKeyHeader))
+		if len(keys) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Header().Set(IdempotentReplayedHeader, "true")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":1,"title":"Test Task"}`))
+	}))
+	defer server.Close()
+
+	task, err := supportingIdempotencyKeys(NewClient(server.URL, WithRetryPolicy(testRetryPolicy))).CreateTask(context.Background(), Task{Title: "Test Task"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, &Task{ID: 1, Title: "Test Task"}, task)
+	assert.Len(t, keys, 2)
+	assert.NotEmpty(t, keys[0])
+	assert.Equal(t, keys[0], keys[1])
+}
+
+func TestCreateTaskUsesContextIdempotencyKey(t *testing.T) {
+	var key string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key = r.Header.Get(IdempotencyKeyHeader)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":1,"title":"Test Task"}`))
+	}))
+	defer server.Close()
+
+	ctx := ContextWithIdempotencyKey(context.Background(), "create-task-1")
+	_, err := supportingIdempotencyKeys(NewClient(server.URL)).CreateTask(ctx, Task{Title: "Test Task"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "create-task-1", key)
+}
+
+func TestCreateTaskNotResentWithoutIdempotencyKeys(t *testing.T) {
+	for name, info := range map[string]*ServerInfo{
+		"unprobed":    nil,
+		"legacy":      {},
+		"unsupported": {Version: "1.0.0", Capabilities: []Capability{CapabilityETags}},
+	} {
+		var mu sync.Mutex
+		var keys []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+			keys = append(keys, r.Header.Get(IdempotencyKeyHeader))
+			// the task is created, but the response is lost
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err != nil {
+				t.Fatal(err)
+			}
+			_ = conn.Close()
+		}))
+		client := NewClient(server.URL, WithRetryPolicy(testRetryPolicy))
+		if info != nil {
+			client.serverInfo.Store(info)
+		}
+
+		_, err := client.CreateTask(ContextWithIdempotencyKey(context.Background(), "create-task-1"), Task{Title: "Test Task"})
+		server.Close()
+
+		assert.Error(t, err, name)
+		assert.Equal(t, []string{""}, keys, name)
+	}
+}
+
+func TestIdempotencyKeyOnlySentOnCreate(t *testing.T) {
+	var key string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key = r.Header.Get(IdempotencyKeyHeader)
+		_, _ = w.Write([]byte(`{"id":1,"title":"Test Task"}`))
+	}))
+	defer server.Close()
+
+	ctx := ContextWithIdempotencyKey(context.Background(), "create-task-1")
+	_, err := NewClient(server.URL).ReadTask(ctx, 1)
+
+	assert.NoError(t, err)
+	assert.Empty(t, key)
+}
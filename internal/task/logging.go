@@ -0,0 +1,137 @@
+package task
+
+import (
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// LogSubsystem is the tflog subsystem logging what the client sends and receives. Its
+// level is set with TF_LOG_PROVIDER_TASKLITE_HTTP, and defaults to the provider log level.
+const LogSubsystem = "http"
+
+// logLevelEnvVars are the environment variables setting the level of LogSubsystem, by
+// precedence.
+var logLevelEnvVars = []string{"TF_LOG_PROVIDER_TASKLITE_HTTP", "TF_LOG_PROVIDER_TASKLITE", "TF_LOG_PROVIDER", "TF_LOG"}
+
+// maxLoggedBodySize caps the size of the bodies logged at TRACE level.
+const maxLoggedBodySize = 64 << 10
+
+// WithRedactedHeaders redacts the values of the given headers from the logged requests and
+// responses, and from recorded cassettes, in addition to DefaultRedactedHeaders.
+func WithRedactedHeaders(names ...string) Option {
+	return func(c *Client) {
+		c.redactedHeaders = append(c.redactedHeaders, names...)
+	}
+}
+
+// WithRedactedLogFields redacts the values of the given JSON and form fields from the
+// logged bodies, in addition to DefaultRedactedFields.
+func WithRedactedLogFields(fields ...string) Option {
+	return func(c *Client) {
+		c.redactedLogFields = append(c.redactedLogFields, fields...)
+	}
+}
+
+// loggingTransport is an http.RoundTripper logging requests and responses in LogSubsystem:
+// the method, URL, status, latency and headers at DEBUG level, and the bodies at TRACE
+// level. Secrets are redacted. Bodies are only buffered when they are logged.
+type loggingTransport struct {
+	base     http.RoundTripper
+	redactor redactor
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := tflog.NewSubsystem(req.Context(), LogSubsystem, tflog.WithLevelFromEnv("TF_LOG_PROVIDER_TASKLITE", LogSubsystem))
+
+	trace := traceLogEnabled()
+	var body []byte
+	if trace {
+		// The RoundTripper contract forbids modifying the caller's request.
+		req = req.Clone(req.Context())
+		var err error
+		if body, err = readBody(&req.Body); err != nil {
+			return nil, err
+		}
+	}
+	tflog.SubsystemDebug(ctx, LogSubsystem, "Sending TaskLite request", map[string]any{
+		"method":  req.Method,
+		"url":     req.URL.String(),
+		"headers": t.redactor.header(req.Header),
+	})
+	if len(body) > 0 {
+		tflog.SubsystemTrace(ctx, LogSubsystem, "TaskLite request body", map[string]any{
+			"method": req.Method,
+			"url":    req.URL.String(),
+			"body":   t.loggedBody(req.Header, body),
+		})
+	}
+
+	start := time.Now()
+	resp, err := t.transport().RoundTrip(req)
+	latency := time.Since(start)
+	if err != nil {
+		tflog.SubsystemDebug(ctx, LogSubsystem, "TaskLite request failed", map[string]any{
+			"method":  req.Method,
+			"url":     req.URL.String(),
+			"latency": latency.String(),
+			"error":   err.Error(),
+		})
+		return nil, err
+	}
+
+	tflog.SubsystemDebug(ctx, LogSubsystem, "Received TaskLite response", map[string]any{
+		"method":  req.Method,
+		"url":     req.URL.String(),
+		"status":  resp.StatusCode,
+		"latency": latency.String(),
+		"headers": t.redactor.header(resp.Header),
+	})
+	if !trace {
+		return resp, nil
+	}
+	respBody, err := readBody(&resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if len(respBody) > 0 {
+		tflog.SubsystemTrace(ctx, LogSubsystem, "TaskLite response body", map[string]any{
+			"method": req.Method,
+			"url":    req.URL.String(),
+			"status": resp.StatusCode,
+			"body":   t.loggedBody(resp.Header, respBody),
+		})
+	}
+
+	return resp, nil
+}
+
+// traceLogEnabled reports whether LogSubsystem logs at TRACE level. tflog does not expose
+// the level, so it is resolved from logLevelEnvVars like Terraform does, JSON meaning TRACE.
+func traceLogEnabled() bool {
+	for _, name := range logLevelEnvVars {
+		if level := strings.TrimSpace(os.Getenv(name)); level != "" {
+			return strings.EqualFold(level, "trace") || strings.EqualFold(level, "json")
+		}
+	}
+	return false
+}
+
+func (t *loggingTransport) transport() http.RoundTripper {
+	if t.base == nil {
+		return http.DefaultTransport
+	}
+	return t.base
+}
+
+// loggedBody returns body, redacted and truncated to maxLoggedBodySize.
+func (t *loggingTransport) loggedBody(header http.Header, body []byte) string {
+	logged := t.redactor.body(header.Get("Content-Type"), body)
+	if len(logged) > maxLoggedBodySize {
+		logged = logged[:maxLoggedBodySize] + "... (truncated)"
+	}
+	return logged
+}
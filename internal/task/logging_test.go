@@ -0,0 +1,200 @@
+package task
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// loggedEntries returns the entries logged in LogSubsystem.
+func loggedEntries(t *testing.T, output *bytes.Buffer) []map[string]any {
+	entries, err := tflogtest.MultilineJSONDecode(output)
+	assert.NoError(t, err)
+	var logged []map[string]any
+	for _, e := range entries {
+		if e["@module"] == "provider."+LogSubsystem {
+			logged = append(logged, e)
+		}
+	}
+	return logged
+}
+
+// loggedHeader returns the values of the header name logged in entry.
+func loggedHeader(entry map[string]any, name string) any {
+	headers, _ := entry["headers"].(map[string]any)
+	return headers[name]
+}
+
+// setLogLevel sets the level of LogSubsystem in the environment for the test.
+func setLogLevel(t *testing.T, level string) {
+	for _, name := range logLevelEnvVars {
+		t.Setenv(name, "")
+	}
+	t.Setenv("TF_LOG_PROVIDER_TASKLITE_HTTP", level)
+}
+
+// roundTripperFunc adapts a function to an http.RoundTripper.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// testBody is a request or response body whose identity can be asserted.
+type testBody struct {
+	io.Reader
+}
+
+func (b *testBody) Close() error {
+	return nil
+}
+
+func TestLoggingTransport(t *testing.T) {
+	setLogLevel(t, "TRACE")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Set-Cookie", "session=secret-session")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":1,"title":"Test Task","api_key":"secret-key"}`))
+	}))
+	defer server.Close()
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+	client := NewClient(server.URL, WithAuthenticator(BearerTokenAuth("secret-token")), WithRedactedLogFields("api_key"))
+
+	_, err := client.CreateTask(ctx, Task{Title: "Test Task"})
+
+	assert.NoError(t, err)
+	assert.NotContains(t, output.String(), "secret-")
+	entries := loggedEntries(t, &output)
+	assert.Len(t, entries, 4)
+
+	assert.Equal(t, "Sending TaskLite request", entries[0]["@message"])
+	assert.Equal(t, "POST", entries[0]["method"])
+	assert.Equal(t, server.URL+TASK_URI, entries[0]["url"])
+	assert.Equal(t, []any{redacted}, loggedHeader(entries[0], "Authorization"))
+
+	assert.Equal(t, "TaskLite request body", entries[1]["@message"])
+	assert.Equal(t, "trace", entries[1]["@level"])
+	assert.Equal(t, `{"title":"Test Task","complete":false,"priority":0}`, entries[1]["body"])
+
+	assert.Equal(t, "Received TaskLite response", entries[2]["@message"])
+	assert.Equal(t, "debug", entries[2]["@level"])
+	assert.Equal(t, float64(http.StatusCreated), entries[2]["status"])
+	assert.NotEmpty(t, entries[2]["latency"])
+	assert.Equal(t, []any{redacted}, loggedHeader(entries[2], "Set-Cookie"))
+
+	assert.Equal(t, "TaskLite response body", entries[3]["@message"])
+	assert.Equal(t, `{"api_key":"REDACTED","id":1,"title":"Test Task"}`, entries[3]["body"])
+}
+
+func TestLoggingTransportRedactedHeaders(t *testing.T) {
+	server := setupTestServer(t, http.MethodGet, Task{ID: 1, Title: "Test Task"}, http.StatusOK)
+	defer server.Close()
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+	client := NewClient(server.URL,
+		WithAuthenticator(HeadersAuth(map[string]string{"X-Api-Key": "s3cr3t-value"})),
+		WithRedactedHeaders("X-Api-Key"),
+	)
+
+	_, err := client.ReadTask(ctx, 1)
+
+	assert.NoError(t, err)
+	assert.NotContains(t, output.String(), "s3cr3t-value")
+	entries := loggedEntries(t, &output)
+	assert.Equal(t, []any{redacted}, loggedHeader(entries[0], "X-Api-Key"))
+}
+
+func TestLoggingTransportError(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	_, err := NewClient(server.URL, WithRetryPolicy(RetryPolicy{})).ReadTask(ctx, 1)
+
+	assert.Error(t, err)
+	entries := loggedEntries(t, &output)
+	assert.Len(t, entries, 2)
+	assert.Equal(t, "TaskLite request failed", entries[1]["@message"])
+	assert.Contains(t, entries[1]["error"], "connection refused")
+}
+
+func TestLoggingTransportBodies(t *testing.T) {
+	for _, level := range []string{"DEBUG", "TRACE"} {
+		t.Run(level, func(t *testing.T) {
+			setLogLevel(t, level)
+			var output bytes.Buffer
+			ctx := tflogtest.RootLogger(context.Background(), &output)
+			reqBody := &testBody{Reader: strings.NewReader(`{"title":"Test Task"}`)}
+			respBody := &testBody{Reader: strings.NewReader(`{"id":1}`)}
+			req, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://tasklite.test"+TASK_URI, reqBody)
+			assert.NoError(t, err)
+			var sent *http.Request
+			lt := &loggingTransport{base: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+				sent = r
+				data, err := io.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.Equal(t, `{"title":"Test Task"}`, string(data))
+				return &http.Response{StatusCode: http.StatusCreated, Header: http.Header{}, Body: respBody}, nil
+			})}
+
+			resp, err := lt.RoundTrip(req)
+
+			assert.NoError(t, err)
+			assert.Same(t, reqBody, req.Body)
+			data, err := io.ReadAll(resp.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, `{"id":1}`, string(data))
+			if level == "TRACE" {
+				assert.NotSame(t, req, sent)
+				assert.Contains(t, output.String(), "TaskLite request body")
+				assert.Contains(t, output.String(), "TaskLite response body")
+			} else {
+				assert.Same(t, req, sent)
+				assert.Same(t, respBody, resp.Body)
+				assert.NotContains(t, output.String(), "body")
+			}
+		})
+	}
+}
+
+func TestTraceLogEnabled(t *testing.T) {
+	tests := map[string]struct {
+		env  map[string]string
+		want bool
+	}{
+		"unset":               {env: map[string]string{}, want: false},
+		"tf_log trace":        {env: map[string]string{"TF_LOG": "TRACE"}, want: true},
+		"tf_log json":         {env: map[string]string{"TF_LOG": "JSON"}, want: true},
+		"tf_log debug":        {env: map[string]string{"TF_LOG": "DEBUG"}, want: false},
+		"provider overrides":  {env: map[string]string{"TF_LOG": "TRACE", "TF_LOG_PROVIDER": "INFO"}, want: false},
+		"subsystem overrides": {env: map[string]string{"TF_LOG_PROVIDER_TASKLITE": "INFO", "TF_LOG_PROVIDER_TASKLITE_HTTP": "trace"}, want: true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			for _, name := range logLevelEnvVars {
+				t.Setenv(name, tt.env[name])
+			}
+
+			assert.Equal(t, tt.want, traceLogEnabled())
+		})
+	}
+}
+
+func TestLoggingTransportTruncatesBodies(t *testing.T) {
+	large := `{"title":"` + strings.Repeat("a", maxLoggedBodySize) + `"}`
+	lt := &loggingTransport{redactor: redactor{fields: DefaultRedactedFields}}
+
+	logged := lt.loggedBody(http.Header{"Content-Type": {"application/json"}}, []byte(large))
+
+	assert.Len(t, logged, maxLoggedBodySize+len("... (truncated)"))
+	assert.True(t, strings.HasSuffix(logged, "... (truncated)"))
+}
@@ -0,0 +1,311 @@
+package task
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Metrics receives measurements of the requests sent by a Client. Implementations must be
+// safe for concurrent use.
+type Metrics interface {
+	// ObserveRequest is called after each request sent to TaskLite, including retries, with
+	// the status of the response, or 0 when none was received, and the latency.
+	ObserveRequest(method string, status int, latency time.Duration)
+	// ObserveRetry is called before a request is retried.
+	ObserveRetry(method string)
+}
+
+// WithMetrics reports measurements of the requests of the client to m. A nil m disables
+// the measurements.
+func WithMetrics(m Metrics) Option {
+	return func(c *Client) {
+		if m == nil {
+			m = noMetrics{}
+		}
+		c.metrics = m
+	}
+}
+
+// noMetrics discards measurements.
+type noMetrics struct{}
+
+func (noMetrics) ObserveRequest(string, int, time.Duration) {}
+
+func (noMetrics) ObserveRetry(string) {}
+
+// DefaultLatencyBuckets are the upper bounds, in seconds, of the latency histogram buckets
+// of PrometheusMetrics.
+var DefaultLatencyBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// PrometheusMetrics is a Metrics counting requests by method and status, and retries by
+// method, and keeping a histogram of the latency of requests by method, written with
+// WriteTo in the Prometheus text exposition format.
+type PrometheusMetrics struct {
+	buckets []float64
+
+	mu        sync.Mutex
+	requests  map[requestKey]uint64
+	latencies map[string]*histogram
+	retries   map[string]uint64
+}
+
+type requestKey struct {
+	method string
+	status int
+}
+
+type histogram struct {
+	counts []uint64 // by bucket, not cumulative
+	sum    float64
+	count  uint64
+}
+
+// NewPrometheusMetrics returns PrometheusMetrics with the given latency buckets, or
+// DefaultLatencyBuckets when none are given.
+func NewPrometheusMetrics(buckets ...float64) *PrometheusMetrics {
+	if len(buckets) == 0 {
+		buckets = DefaultLatencyBuckets
+	}
+	buckets = slices.Clone(buckets)
+	slices.Sort(buckets)
+	return &PrometheusMetrics{
+		buckets:   buckets,
+		requests:  map[requestKey]uint64{},
+		latencies: map[string]*histogram{},
+		retries:   map[string]uint64{},
+	}
+}
+
+func (m *PrometheusMetrics) ObserveRequest(method string, status int, latency time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests[requestKey{method, status}]++
+	h := m.histogram(method)
+	seconds := latency.Seconds()
+	if i, _ := slices.BinarySearch(m.buckets, seconds); i < len(m.buckets) {
+		h.counts[i]++
+	}
+	h.sum += seconds
+	h.count++
+}
+
+func (m *PrometheusMetrics) ObserveRetry(method string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.retries[method]++
+}
+
+// WriteTo writes the metrics to w in the Prometheus text exposition format.
+func (m *PrometheusMetrics) WriteTo(w io.Writer) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var b strings.Builder
+
+	b.WriteString("# HELP tasklite_client_requests_total Requests sent to the TaskLite API, by method and response status.\n")
+	b.WriteString("# TYPE tasklite_client_requests_total counter\n")
+	keys := make([]requestKey, 0, len(m.requests))
+	for k := range m.requests {
+		keys = append(keys, k)
+	}
+	slices.SortFunc(keys, func(a, b requestKey) int {
+		if c := strings.Compare(a.method, b.method); c != 0 {
+			return c
+		}
+		return a.status - b.status
+	})
+	for _, k := range keys {
+		fmt.Fprintf(&b, "tasklite_client_requests_total{method=%q,status=%q} %d\n", k.method, statusLabel(k.status), m.requests[k])
+	}
+
+	b.WriteString("# HELP tasklite_client_request_duration_seconds Latency of the requests sent to the TaskLite API, by method.\n")
+	b.WriteString("# TYPE tasklite_client_request_duration_seconds histogram\n")
+	for _, method := range sortedKeys(m.latencies) {
+		h := m.latencies[method]
+		var cumulative uint64
+		for i, le := range m.buckets {
+			cumulative += h.counts[i]
+			fmt.Fprintf(&b, "tasklite_client_request_duration_seconds_bucket{method=%q,le=%q} %d\n", method, formatFloat(le), cumulative)
+		}
+		fmt.Fprintf(&b, "tasklite_client_request_duration_seconds_bucket{method=%q,le=\"+Inf\"} %d\n", method, h.count)
+		fmt.Fprintf(&b, "tasklite_client_request_duration_seconds_sum{method=%q} %s\n", method, formatFloat(h.sum))
+		fmt.Fprintf(&b, "tasklite_client_request_duration_seconds_count{method=%q} %d\n", method, h.count)
+	}
+
+	b.WriteString("# HELP tasklite_client_retries_total Requests to the TaskLite API retried, by method.\n")
+	b.WriteString("# TYPE tasklite_client_retries_total counter\n")
+	for _, method := range sortedKeys(m.retries) {
+		fmt.Fprintf(&b, "tasklite_client_retries_total{method=%q} %d\n", method, m.retries[method])
+	}
+
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+// IsEmpty reports whether no request was observed.
+func (m *PrometheusMetrics) IsEmpty() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return len(m.requests) == 0 && len(m.retries) == 0
+}
+
+// sampleLabel matches a label of a sample, whose value is quoted.
+var sampleLabel = regexp.MustCompile(`(\w+)=("(?:[^"\\]|\\.)*")`)
+
+// Merge adds the metrics written by WriteTo to r to m, so that the metrics of successive
+// processes can be accumulated. The latency buckets written to r must be those of m. When
+// it fails, m is left unchanged.
+func (m *PrometheusMetrics) Merge(r io.Reader) error {
+	read := NewPrometheusMetrics(m.buckets...)
+	if err := read.read(r); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for k, n := range read.requests {
+		m.requests[k] += n
+	}
+	for method, n := range read.retries {
+		m.retries[method] += n
+	}
+	for method, r := range read.latencies {
+		h := m.histogram(method)
+		for i, n := range r.counts {
+			h.counts[i] += n
+		}
+		h.sum += r.sum
+		h.count += r.count
+	}
+	return nil
+}
+
+// read parses the metrics written by WriteTo to r into m, which must be new.
+func (m *PrometheusMetrics) read(r io.Reader) error {
+	// the bucket counts are cumulative, so the previous count of each method is subtracted
+	previous := map[string]uint64{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		series, value, ok := strings.Cut(line, " ")
+		if !ok {
+			return fmt.Errorf("invalid metrics sample %q", line)
+		}
+		name, _, _ := strings.Cut(series, "{")
+		labels := map[string]string{}
+		for _, match := range sampleLabel.FindAllStringSubmatch(series, -1) {
+			v, err := strconv.Unquote(match[2])
+			if err != nil {
+				return fmt.Errorf("invalid label in metrics sample %q: %w", line, err)
+			}
+			labels[match[1]] = v
+		}
+
+		if err := m.mergeSample(name, labels, value, previous); err != nil {
+			return fmt.Errorf("invalid metrics sample %q: %w", line, err)
+		}
+	}
+	return scanner.Err()
+}
+
+// mergeSample adds the sample of the metric name with labels and value to m.
+func (m *PrometheusMetrics) mergeSample(name string, labels map[string]string, value string, previous map[string]uint64) error {
+	method := labels["method"]
+	if name == "tasklite_client_request_duration_seconds_sum" {
+		sum, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+		m.histogram(method).sum += sum
+		return nil
+	}
+
+	n, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return err
+	}
+	switch name {
+	case "tasklite_client_requests_total":
+		status := 0
+		if labels["status"] != "error" {
+			if status, err = strconv.Atoi(labels["status"]); err != nil {
+				return err
+			}
+		}
+		m.requests[requestKey{method, status}] += n
+	case "tasklite_client_retries_total":
+		m.retries[method] += n
+	case "tasklite_client_request_duration_seconds_count":
+		m.histogram(method).count += n
+	case "tasklite_client_request_duration_seconds_bucket":
+		if labels["le"] == "+Inf" {
+			return nil
+		}
+		le, err := strconv.ParseFloat(labels["le"], 64)
+		if err != nil {
+			return err
+		}
+		i, found := slices.BinarySearch(m.buckets, le)
+		if !found || n < previous[method] {
+			return errors.New("latency buckets differ")
+		}
+		m.histogram(method).counts[i] += n - previous[method]
+		previous[method] = n
+	}
+	return nil
+}
+
+// histogram returns the latency histogram of method, creating it if needed. m.mu must be held.
+func (m *PrometheusMetrics) histogram(method string) *histogram {
+	h := m.latencies[method]
+	if h == nil {
+		h = &histogram{counts: make([]uint64, len(m.buckets))}
+		m.latencies[method] = h
+	}
+	return h
+}
+
+// statusLabel returns the status label of requests answered with status, "error" for
+// requests that failed without a response.
+func statusLabel(status int) string {
+	if status == 0 {
+		return "error"
+	}
+	return strconv.Itoa(status)
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// responseStatus returns the status of resp, 0 when there is none.
+func responseStatus(resp *http.Response) int {
+	if resp == nil {
+		return 0
+	}
+	return resp.StatusCode
+}
@@ -0,0 +1,126 @@
+package task
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientMetrics(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":1,"title":"Test Task"}`))
+	}))
+	defer server.Close()
+	metrics := NewPrometheusMetrics()
+	client := NewClient(server.URL, WithMetrics(metrics), WithRetryPolicy(testRetryPolicy))
+
+	_, err := client.ReadTask(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[requestKey]uint64{{"GET", 503}: 1, {"GET", 200}: 1}, metrics.requests)
+	assert.Equal(t, map[string]uint64{"GET": 1}, metrics.retries)
+	assert.Equal(t, uint64(2), metrics.latencies["GET"].count)
+}
+
+func TestClientMetricsConnectionError(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+	metrics := NewPrometheusMetrics()
+
+	err := NewClient(server.URL, WithMetrics(metrics), WithRetryPolicy(RetryPolicy{})).DeleteTask(context.Background(), 1)
+
+	assert.Error(t, err)
+	assert.Equal(t, map[requestKey]uint64{{"DELETE", 0}: 1}, metrics.requests)
+	assert.Empty(t, metrics.retries)
+}
+
+func TestPrometheusMetricsWriteTo(t *testing.T) {
+	metrics := NewPrometheusMetrics(1, 0.1)
+	metrics.ObserveRequest(http.MethodPost, http.StatusCreated, 50*time.Millisecond)
+	metrics.ObserveRequest(http.MethodGet, http.StatusOK, 500*time.Millisecond)
+	metrics.ObserveRequest(http.MethodGet, 0, 2*time.Second)
+	metrics.ObserveRequest(http.MethodGet, http.StatusOK, 250*time.Millisecond)
+	metrics.ObserveRetry(http.MethodGet)
+
+	var b bytes.Buffer
+	n, err := metrics.WriteTo(&b)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(b.Len()), n)
+	assert.Equal(t, `# HELP tasklite_client_requests_total Requests sent to the TaskLite API, by method and response status.
+# TYPE tasklite_client_requests_total counter
+tasklite_client_requests_total{method="GET",status="error"} 1
+tasklite_client_requests_total{method="GET",status="200"} 2
+tasklite_client_requests_total{method="POST",status="201"} 1
+# HELP tasklite_client_request_duration_seconds Latency of the requests sent to the TaskLite API, by method.
+# TYPE tasklite_client_request_duration_seconds histogram
+tasklite_client_request_duration_seconds_bucket{method="GET",le="0.1"} 0
+tasklite_client_request_duration_seconds_bucket{method="GET",le="1"} 2
+tasklite_client_request_duration_seconds_bucket{method="GET",le="+Inf"} 3
+tasklite_client_request_duration_seconds_sum{method="GET"} 2.75
+tasklite_client_request_duration_seconds_count{method="GET"} 3
+tasklite_client_request_duration_seconds_bucket{method="POST",le="0.1"} 1
+tasklite_client_request_duration_seconds_bucket{method="POST",le="1"} 1
+tasklite_client_request_duration_seconds_bucket{method="POST",le="+Inf"} 1
+tasklite_client_request_duration_seconds_sum{method="POST"} 0.05
+tasklite_client_request_duration_seconds_count{method="POST"} 1
+# HELP tasklite_client_retries_total Requests to the TaskLite API retried, by method.
+# TYPE tasklite_client_retries_total counter
+tasklite_client_retries_total{method="GET"} 1
+`, b.String())
+}
+
+func TestPrometheusMetricsMerge(t *testing.T) {
+	observe := func(m *PrometheusMetrics) {
+		m.ObserveRequest(http.MethodGet, http.StatusOK, 50*time.Millisecond)
+		m.ObserveRequest(http.MethodGet, 0, 2*time.Second)
+		m.ObserveRetry(http.MethodGet)
+	}
+	previous := NewPrometheusMetrics()
+	observe(previous)
+	var written bytes.Buffer
+	_, err := previous.WriteTo(&written)
+	assert.NoError(t, err)
+	metrics := NewPrometheusMetrics()
+	assert.True(t, metrics.IsEmpty())
+	metrics.ObserveRequest(http.MethodPost, http.StatusCreated, 50*time.Millisecond)
+
+	err = metrics.Merge(&written)
+
+	assert.NoError(t, err)
+	want := NewPrometheusMetrics()
+	observe(want)
+	want.ObserveRequest(http.MethodPost, http.StatusCreated, 50*time.Millisecond)
+	var got, wanted bytes.Buffer
+	_, _ = metrics.WriteTo(&got)
+	_, _ = want.WriteTo(&wanted)
+	assert.Equal(t, wanted.String(), got.String())
+}
+
+func TestPrometheusMetricsMergeInvalid(t *testing.T) {
+	var written bytes.Buffer
+	other := NewPrometheusMetrics(1, 2)
+	other.ObserveRequest(http.MethodGet, http.StatusOK, time.Second)
+	_, _ = other.WriteTo(&written)
+
+	assert.ErrorContains(t, NewPrometheusMetrics().Merge(&written), "latency buckets differ")
+	assert.Error(t, NewPrometheusMetrics().Merge(strings.NewReader("tasklite_client_retries_total{method=\"GET\"} many\n")))
+
+	// the samples read before the invalid one are not merged
+	metrics := NewPrometheusMetrics()
+	err := metrics.Merge(strings.NewReader("tasklite_client_retries_total{method=\"GET\"} 1\ntasklite_client_retries_total{method=\"PUT\"} many\n"))
+	assert.Error(t, err)
+	assert.True(t, metrics.IsEmpty())
+}
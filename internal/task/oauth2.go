@@ -0,0 +1,124 @@
+package task
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// OAuth2Config describes an OAuth2 client credentials grant used to obtain access tokens.
+type OAuth2Config struct {
+	TokenURL     string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+	Audience     string
+}
+
+// maxTokenExpiryDelta is how long before expiry an access token is refreshed, at most.
+const maxTokenExpiryDelta = 30 * time.Second
+
+// WithOAuth2 authenticates requests with access tokens obtained from cfg.TokenURL. Tokens
+// are cached, refreshed before they expire, and discarded when the server answers 401.
+func WithOAuth2(cfg OAuth2Config) Option {
+	return WithAuthenticator(&oauth2Authenticator{config: cfg})
+}
+
+// oauth2Authenticator is a RefreshableAuthenticator sending OAuth2 access tokens.
+type oauth2Authenticator struct {
+	config    OAuth2Config
+	transport http.RoundTripper
+
+	mu          sync.Mutex
+	accessToken string
+	refreshAt   time.Time
+}
+
+// oauth2Token is the token endpoint response.
+type oauth2Token struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+func (a *oauth2Authenticator) setTransport(t http.RoundTripper) {
+	a.transport = t
+}
+
+func (a *oauth2Authenticator) Authenticate(req *http.Request) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.accessToken == "" || !time.Now().Before(a.refreshAt) {
+		if err := a.fetchToken(req); err != nil {
+			return err
+		}
+	}
+
+	req.Header.Set("Authorization", "Bearer "+a.accessToken)
+	return nil
+}
+
+func (a *oauth2Authenticator) Invalidate() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.accessToken = ""
+}
+
+// fetchToken requests a new access token. It must be called with a.mu held.
+func (a *oauth2Authenticator) fetchToken(req *http.Request) error {
+	form := url.Values{"grant_type": {"client_credentials"}}
+	if len(a.config.Scopes) > 0 {
+		form.Set("scope", strings.Join(a.config.Scopes, " "))
+	}
+	if a.config.Audience != "" {
+		form.Set("audience", a.config.Audience)
+	}
+
+	tokenReq, err := http.NewRequestWithContext(req.Context(), http.MethodPost, a.config.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	tokenReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	tokenReq.Header.Set("Accept", "application/json")
+	tokenReq.SetBasicAuth(url.QueryEscape(a.config.ClientID), url.QueryEscape(a.config.ClientSecret))
+
+	transport := a.transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	resp, err := (&http.Client{Transport: transport}).Do(tokenReq)
+	if err != nil {
+		return fmt.Errorf("failed to obtain OAuth2 access token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to obtain OAuth2 access token: %w", newAPIError(resp))
+	}
+
+	var token oauth2Token
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return fmt.Errorf("failed to decode OAuth2 token response: %w", err)
+	}
+	if token.AccessToken == "" {
+		return fmt.Errorf("OAuth2 token response from %s has no access_token", a.config.TokenURL)
+	}
+	if token.TokenType != "" && !strings.EqualFold(token.TokenType, "bearer") {
+		return fmt.Errorf("unsupported OAuth2 token type %q", token.TokenType)
+	}
+
+	a.accessToken = token.AccessToken
+	if lifetime := time.Duration(token.ExpiresIn) * time.Second; lifetime > 0 {
+		a.refreshAt = time.Now().Add(lifetime - min(maxTokenExpiryDelta, lifetime/2))
+	} else {
+		// no expiry given, keep the token until the server rejects it
+		a.refreshAt = time.Now().Add(100 * 365 * 24 * time.Hour)
+	}
+
+	return nil
+}
@@ -0,0 +1,141 @@
+package task
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// oauth2TestServer issues access tokens and serves tasks to requests carrying the latest one.
+type oauth2TestServer struct {
+	mu        sync.Mutex
+	issued    int
+	expiresIn int
+	current   string
+	forms     []map[string]string
+
+	tokenServer *httptest.Server
+	apiServer   *httptest.Server
+}
+
+func newOAuth2TestServer(t *testing.T, expiresIn int) *oauth2TestServer {
+	s := &oauth2TestServer{expiresIn: expiresIn}
+	s.tokenServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if id, secret, ok := r.BasicAuth(); !ok || id != "client" || secret != "s3cr3t" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":"invalid_client"}`))
+			return
+		}
+		if err := r.ParseForm(); err != nil {
+			t.Fatal(err)
+		}
+		s.forms = append(s.forms, map[string]string{
+			"grant_type": r.PostForm.Get("grant_type"),
+			"scope":      r.PostForm.Get("scope"),
+			"audience":   r.PostForm.Get("audience"),
+		})
+		s.issued++
+		s.current = fmt.Sprintf("token-%d", s.issued)
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token": s.current,
+			"token_type":   "Bearer",
+			"expires_in":   s.expiresIn,
+		})
+	}))
+	s.apiServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if r.Header.Get("Authorization") != "Bearer "+s.current {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":1,"title":"Test Task"}`))
+	}))
+	return s
+}
+
+func (s *oauth2TestServer) Close() {
+	s.tokenServer.Close()
+	s.apiServer.Close()
+}
+
+func (s *oauth2TestServer) client(clientSecret string) *Client {
+	return NewClient(s.apiServer.URL, WithRetryPolicy(RetryPolicy{}), WithOAuth2(OAuth2Config{
+		TokenURL:     s.tokenServer.URL,
+		ClientID:     "client",
+		ClientSecret: clientSecret,
+		Scopes:       []string{"tasks:read", "tasks:write"},
+		Audience:     "tasklite",
+	}))
+}
+
+func TestOAuth2CachesToken(t *testing.T) {
+	s := newOAuth2TestServer(t, 3600)
+	defer s.Close()
+	c := s.client("s3cr3t")
+
+	for i := 0; i < 3; i++ {
+		_, err := c.ReadTask(context.Background(), 1)
+		assert.NoError(t, err)
+	}
+
+	assert.Equal(t, 1, s.issued)
+	assert.Equal(t, []map[string]string{{
+		"grant_type": "client_credentials",
+		"scope":      "tasks:read tasks:write",
+		"audience":   "tasklite",
+	}}, s.forms)
+}
+
+func TestOAuth2RefreshesTokenBeforeExpiry(t *testing.T) {
+	// a token valid for 1 second is refreshed after half its lifetime
+	s := newOAuth2TestServer(t, 1)
+	defer s.Close()
+	c := s.client("s3cr3t")
+
+	_, err := c.ReadTask(context.Background(), 1)
+	assert.NoError(t, err)
+	time.Sleep(600 * time.Millisecond)
+	_, err = c.ReadTask(context.Background(), 1)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 2, s.issued)
+}
+
+func TestOAuth2RetriesOnceOnUnauthorized(t *testing.T) {
+	s := newOAuth2TestServer(t, 3600)
+	defer s.Close()
+	c := s.client("s3cr3t")
+
+	_, err := c.ReadTask(context.Background(), 1)
+	assert.NoError(t, err)
+
+	// the server revokes the cached token
+	s.mu.Lock()
+	s.current = "revoked"
+	s.mu.Unlock()
+
+	task, err := c.CreateTask(context.Background(), Task{Title: "Test Task"})
+	assert.NoError(t, err)
+	assert.Equal(t, &Task{ID: 1, Title: "Test Task"}, task)
+	assert.Equal(t, 2, s.issued)
+}
+
+func TestOAuth2TokenError(t *testing.T) {
+	s := newOAuth2TestServer(t, 3600)
+	defer s.Close()
+
+	_, err := s.client("wrong").ReadTask(context.Background(), 1)
+
+	assert.ErrorContains(t, err, "failed to obtain OAuth2 access token")
+	assert.ErrorContains(t, err, "invalid_client")
+}
@@ -0,0 +1,141 @@
+package task
+
+import (
+	"context"
+	"io"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// WithRateLimit limits the client to requestsPerSecond requests on average, allowing
+// bursts of up to one second worth of requests. Every retry counts as a request. Zero
+// means no limit.
+func WithRateLimit(requestsPerSecond float64) Option {
+	return func(c *Client) {
+		c.limiter.rate = requestsPerSecond
+		c.limiter.burst = max(1, math.Floor(requestsPerSecond))
+	}
+}
+
+// WithMaxConcurrentRequests limits the number of requests in flight at once. A request is
+// in flight until its response body is closed. Zero means no limit.
+func WithMaxConcurrentRequests(n int) Option {
+	return func(c *Client) {
+		c.limiter.slots = nil
+		if n > 0 {
+			c.limiter.slots = make(chan struct{}, n)
+		}
+	}
+}
+
+// rateLimiter throttles the requests of a client. It combines a token bucket, implemented
+// as a virtual schedule of request start times, with a semaphore capping the requests in
+// flight. When the server answers 429 with Retry-After, all requests are paused for that
+// long rather than only the rejected one backing off.
+type rateLimiter struct {
+	rate     float64
+	burst    float64
+	slots    chan struct{}
+	maxPause time.Duration
+
+	mu          sync.Mutex
+	next        time.Time
+	pausedUntil time.Time
+}
+
+// reserve returns how long the caller must wait before starting its request.
+func (l *rateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	start := now
+	if start.Before(l.pausedUntil) {
+		start = l.pausedUntil
+	}
+	if l.rate > 0 {
+		interval := time.Duration(float64(time.Second) / l.rate)
+		if l.next.Before(start) {
+			l.next = start
+		}
+		// up to burst requests may start before their scheduled time
+		if earliest := l.next.Add(-time.Duration(l.burst-1) * interval); earliest.After(start) {
+			start = earliest
+		}
+		l.next = l.next.Add(interval)
+	}
+	return start.Sub(now)
+}
+
+// pause delays all requests by d, capped at maxPause.
+func (l *rateLimiter) pause(d time.Duration) {
+	if l.maxPause > 0 && d > l.maxPause {
+		d = l.maxPause
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if until := time.Now().Add(d); until.After(l.pausedUntil) {
+		l.pausedUntil = until
+	}
+}
+
+// acquire waits for a free slot and the rate limit, and returns the function releasing
+// the slot.
+func (l *rateLimiter) acquire(ctx context.Context) (func(), error) {
+	release := func() {}
+	if l.slots != nil {
+		select {
+		case l.slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		release = func() { <-l.slots }
+	}
+
+	if wait := l.reserve(); wait > 0 {
+		tflog.Debug(ctx, "Throttling TaskLite request", map[string]any{"wait": wait.String()})
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			release()
+			return nil, ctx.Err()
+		}
+	}
+
+	return release, nil
+}
+
+// done records the outcome of a request started after acquire. The slot is released once
+// the response body is closed, or immediately when there is no response.
+func (l *rateLimiter) done(ctx context.Context, resp *http.Response, release func()) {
+	if resp == nil {
+		release()
+		return
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		if after, ok := retryAfter(resp); ok && after > 0 {
+			tflog.Debug(ctx, "TaskLite asked to slow down, pausing requests", map[string]any{"retry_after": after.String()})
+			l.pause(after)
+		}
+	}
+
+	resp.Body = &releaseOnClose{ReadCloser: resp.Body, release: release}
+}
+
+// releaseOnClose calls release once when the body is closed.
+type releaseOnClose struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (b *releaseOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
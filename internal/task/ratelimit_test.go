@@ -0,0 +1,100 @@
+package task
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimit(t *testing.T) {
+	server := setupTestServer(t, http.MethodGet, Task{ID: 1, Title: "Test Task"}, http.StatusOK)
+	defer server.Close()
+	client := NewClient(server.URL, WithRateLimit(20))
+
+	// a burst of 20 requests is allowed, the next 5 are spaced 50ms apart
+	start := time.Now()
+	for i := 0; i < 25; i++ {
+		_, err := client.ReadTask(context.Background(), 1)
+		assert.NoError(t, err)
+	}
+
+	assert.GreaterOrEqual(t, time.Since(start), 200*time.Millisecond)
+}
+
+func TestRateLimitTimeout(t *testing.T) {
+	server := setupTestServer(t, http.MethodGet, Task{ID: 1, Title: "Test Task"}, http.StatusOK)
+	defer server.Close()
+	client := NewClient(server.URL, WithRateLimit(1))
+
+	_, err := client.ReadTask(context.Background(), 1)
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = client.ReadTask(ctx, 1)
+
+	var timeoutErr *TimeoutError
+	assert.ErrorAs(t, err, &timeoutErr)
+}
+
+func TestMaxConcurrentRequests(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			m := maxInFlight.Load()
+			if n <= m || maxInFlight.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		_, _ = w.Write([]byte(`{"id":1,"title":"Test Task"}`))
+	}))
+	defer server.Close()
+	client := NewClient(server.URL, WithMaxConcurrentRequests(2))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.ReadTask(context.Background(), 1)
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(2), maxInFlight.Load())
+}
+
+func TestTooManyRequestsPausesAllRequests(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":1,"title":"Test Task"}`))
+	}))
+	defer server.Close()
+	// the pause is capped at the maximum backoff
+	client := NewClient(server.URL, WithRetryPolicy(RetryPolicy{MaxBackoff: 200 * time.Millisecond}))
+
+	_, err := client.ReadTask(context.Background(), 1)
+	assert.ErrorContains(t, err, "HTTP 429")
+
+	start := time.Now()
+	_, err = client.ReadTask(context.Background(), 2)
+	assert.NoError(t, err)
+	elapsed := time.Since(start)
+	assert.GreaterOrEqual(t, elapsed, 150*time.Millisecond)
+	assert.Less(t, elapsed, 900*time.Millisecond)
+}
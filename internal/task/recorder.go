@@ -0,0 +1,324 @@
+package task
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// RecordModeEnvVar is the environment variable read by RecordModeFromEnv.
+const RecordModeEnvVar = "TASKLITE_RECORD_MODE"
+
+// RecordMode tells a Recorder whether to record or replay requests.
+type RecordMode string
+
+const (
+	// RecordModeReplay answers requests with the recorded responses, without any network access.
+	RecordModeReplay RecordMode = "replay"
+	// RecordModeRecord sends requests to the server and records them with their responses.
+	RecordModeRecord RecordMode = "record"
+	// RecordModeDisabled sends requests to the server without recording them.
+	RecordModeDisabled RecordMode = "disabled"
+)
+
+// RecordModeFromEnv returns the mode set with TASKLITE_RECORD_MODE, RecordModeReplay when
+// it is not set.
+func RecordModeFromEnv() (RecordMode, error) {
+	switch mode := RecordMode(os.Getenv(RecordModeEnvVar)); mode {
+	case "":
+		return RecordModeReplay, nil
+	case RecordModeReplay, RecordModeRecord, RecordModeDisabled:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("invalid %s %q, expected %q, %q or %q", RecordModeEnvVar, mode, RecordModeReplay, RecordModeRecord, RecordModeDisabled)
+	}
+}
+
+// Interaction is a request and its response, as stored in a cassette.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is a request stored in a cassette.
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// RecordedResponse is a response stored in a cassette.
+type RecordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// cassette is the content of a cassette file.
+type cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Recorder records the requests of the clients using it to a cassette file, and replays
+// them, so that tests run without a server. Secrets in the headers and fields listed in
+// RedactedHeaders and RedactedFields are replaced before they are written.
+//
+// Requests are replayed in the recorded order: each request is answered with the response
+// of the first unused recorded request with the same method, path, query and body. GET
+// and HEAD requests see the server as left by the last replayed change, such as a POST:
+// they are answered in order up to the next recorded change, then the last response is
+// repeated, so that a Terraform version reading or probing the server more often than the
+// recording one still replays the cassette.
+type Recorder struct {
+	RedactedHeaders []string
+	RedactedFields  []string
+
+	path string
+	mode RecordMode
+
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+	// changed is the index of the last replayed change, -1 before any.
+	changed int
+}
+
+// NewRecorder returns a recorder for the cassette at path, which is read in
+// RecordModeReplay.
+func NewRecorder(path string, mode RecordMode) (*Recorder, error) {
+	r := &Recorder{
+		RedactedHeaders: slices.Clone(DefaultRedactedHeaders),
+		RedactedFields:  slices.Clone(DefaultRedactedFields),
+		path:            path,
+		mode:            mode,
+		changed:         -1,
+	}
+	if mode != RecordModeReplay {
+		return r, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("cassette %s not found, record it with %s=%s", path, RecordModeEnvVar, RecordModeRecord)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var c cassette
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("invalid cassette %s: %w", path, err)
+	}
+	r.interactions = c.Interactions
+	r.used = make([]bool, len(c.Interactions))
+	return r, nil
+}
+
+// Mode returns the mode of the recorder.
+func (r *Recorder) Mode() RecordMode {
+	return r.mode
+}
+
+// Save writes the recorded interactions to the cassette in RecordModeRecord, and does
+// nothing in the other modes.
+func (r *Recorder) Save() error {
+	if r.mode != RecordModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	data, err := json.MarshalIndent(cassette{Interactions: r.interactions}, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, append(data, '\n'), 0o644)
+}
+
+// WithRecorder makes the client record or replay its requests with r, beneath
+// authentication so that the recorded credentials are redacted.
+func WithRecorder(r *Recorder) Option {
+	return func(c *Client) {
+		c.recorder = r
+	}
+}
+
+// recordingTransport is an http.RoundTripper recording or replaying requests with a Recorder.
+type recordingTransport struct {
+	recorder *Recorder
+	base     http.RoundTripper
+	// redactedHeaders are redacted in addition to the RedactedHeaders of the recorder.
+	redactedHeaders []string
+}
+
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	r := t.recorder
+	if r.mode == RecordModeDisabled {
+		return t.transport().RoundTrip(req)
+	}
+
+	body, err := readBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+	redact := r.redactor()
+	redact.headers = append(slices.Clone(redact.headers), t.redactedHeaders...)
+	recorded := RecordedRequest{
+		Method: req.Method,
+		URL:    req.URL.String(),
+		Header: redact.header(req.Header),
+		Body:   redact.body(req.Header.Get("Content-Type"), body),
+	}
+
+	if r.mode == RecordModeReplay {
+		return r.replay(req, recorded)
+	}
+
+	resp, err := t.transport().RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := readBody(&resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.interactions = append(r.interactions, Interaction{
+		Request: recorded,
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     redact.header(resp.Header),
+			Body:       redact.body(resp.Header.Get("Content-Type"), respBody),
+		},
+	})
+	return resp, nil
+}
+
+func (t *recordingTransport) transport() http.RoundTripper {
+	if t.base == nil {
+		return http.DefaultTransport
+	}
+	return t.base
+}
+
+// replay answers req with the response of the interaction found for recorded.
+func (r *Recorder) replay(req *http.Request, recorded RecordedRequest) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if i := r.find(recorded); i >= 0 {
+		in := r.interactions[i]
+		r.used[i] = true
+		if !isRead(recorded.Method) {
+			r.changed = i
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", in.Response.StatusCode, http.StatusText(in.Response.StatusCode)),
+			StatusCode:    in.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        in.Response.Header.Clone(),
+			Body:          io.NopCloser(strings.NewReader(in.Response.Body)),
+			ContentLength: int64(len(in.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("no recorded response for %s %s in cassette %s, record it again with %s=%s", req.Method, req.URL, r.path, RecordModeEnvVar, RecordModeRecord)
+}
+
+// find returns the index of the interaction answering recorded, or -1 when there is none.
+// r.mu must be held.
+func (r *Recorder) find(recorded RecordedRequest) int {
+	if !isRead(recorded.Method) {
+		for i, in := range r.interactions {
+			if !r.used[i] && matches(in.Request, recorded) {
+				return i
+			}
+		}
+		return -1
+	}
+
+	// the first unused read before the next change, or the last one used
+	last := -1
+	for i := r.changed + 1; i < len(r.interactions); i++ {
+		in := r.interactions[i]
+		if !r.used[i] && !isRead(in.Request.Method) {
+			break
+		}
+		if !matches(in.Request, recorded) {
+			continue
+		}
+		if !r.used[i] {
+			return i
+		}
+		last = i
+	}
+	if last >= 0 {
+		return last
+	}
+	// reads recorded only before the last change, e.g. of the server version
+	for i := r.changed; i >= 0; i-- {
+		if matches(r.interactions[i].Request, recorded) {
+			return i
+		}
+	}
+	return -1
+}
+
+// isRead reports whether requests with method do not change the server.
+func isRead(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead
+}
+
+// redactor returns the redactor of the secrets of the recorder.
+func (r *Recorder) redactor() redactor {
+	return redactor{headers: r.RedactedHeaders, fields: r.RedactedFields}
+}
+
+// matches reports whether a request recorded as got is answered by the interaction of want.
+// Hosts are not compared, so that cassettes can be replayed against any host.
+func matches(want, got RecordedRequest) bool {
+	wantURL, err := url.Parse(want.URL)
+	if err != nil {
+		return false
+	}
+	gotURL, err := url.Parse(got.URL)
+	if err != nil {
+		return false
+	}
+	return want.Method == got.Method &&
+		wantURL.Path == gotURL.Path &&
+		wantURL.RawQuery == gotURL.RawQuery &&
+		want.Body == got.Body
+}
+
+// readBody reads *body and replaces it with a reader of the same content.
+func readBody(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+	data, err := io.ReadAll(*body)
+	_ = (*body).Close()
+	if err != nil {
+		return nil, err
+	}
+	*body = io.NopCloser(bytes.NewReader(data))
+	return data, nil
+}
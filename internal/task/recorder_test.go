@@ -0,0 +1,167 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// setupCountingServer serves tasks named after the number of requests received so far.
+func setupCountingServer(t *testing.T) (*httptest.Server, *atomic.Int32) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := calls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=secret-session")
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusCreated)
+		}
+		_, _ = fmt.Fprintf(w, `{"id":1,"title":"Task %d","priority":0,"complete":false}`, n)
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func TestRecorderRecordAndReplay(t *testing.T) {
+	server, calls := setupCountingServer(t)
+	path := filepath.Join(t.TempDir(), "cassettes", "test.json")
+
+	recorder, err := NewRecorder(path, RecordModeRecord)
+	assert.NoError(t, err)
+	client := NewClient(server.URL,
+		WithRecorder(recorder),
+		WithAuthenticator(BearerTokenAuth("secret-token")),
+		WithAuthenticator(HeadersAuth(map[string]string{"X-Api-Key": "secret-key"})),
+		WithRedactedHeaders("x-api-key"),
+	)
+	first, err := client.ReadTask(context.Background(), 1)
+	assert.NoError(t, err)
+	second, err := client.ReadTask(context.Background(), 1)
+	assert.NoError(t, err)
+	created, err := client.CreateTask(context.Background(), Task{Title: "New task"})
+	assert.NoError(t, err)
+	assert.NoError(t, recorder.Save())
+	assert.Equal(t, int32(3), calls.Load())
+
+	cassette, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(cassette), "secret-token")
+	assert.NotContains(t, string(cassette), "secret-session")
+	assert.NotContains(t, string(cassette), "secret-key")
+	assert.Contains(t, string(cassette), redacted)
+
+	// the responses are replayed in order, without contacting the server, on any host
+	replayer, err := NewRecorder(path, RecordModeReplay)
+	assert.NoError(t, err)
+	client = NewClient("http://127.0.0.1:1", WithRecorder(replayer))
+	task, err := client.ReadTask(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, first, task)
+	task, err = client.ReadTask(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, second, task)
+	task, err = client.CreateTask(context.Background(), Task{Title: "New task"})
+	assert.NoError(t, err)
+	assert.Equal(t, created, task)
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestRecorderReplayMismatch(t *testing.T) {
+	server, _ := setupCountingServer(t)
+	path := filepath.Join(t.TempDir(), "test.json")
+	recorder, err := NewRecorder(path, RecordModeRecord)
+	assert.NoError(t, err)
+	_, err = NewClient(server.URL, WithRecorder(recorder)).ReadTask(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.NoError(t, recorder.Save())
+
+	replayer, err := NewRecorder(path, RecordModeReplay)
+	assert.NoError(t, err)
+	client := NewClient(server.URL, WithRecorder(replayer), WithRetryPolicy(RetryPolicy{}))
+
+	_, err = client.ReadTask(context.Background(), 2)
+	assert.ErrorContains(t, err, "no recorded response for GET "+server.URL+"/api/task/2/")
+	_, err = client.ReadTask(context.Background(), 1)
+	assert.NoError(t, err)
+	err = client.DeleteTask(context.Background(), 1)
+	assert.ErrorContains(t, err, "no recorded response for DELETE")
+}
+
+func TestRecorderReplayRepeatedReads(t *testing.T) {
+	server, _ := setupCountingServer(t)
+	path := filepath.Join(t.TempDir(), "test.json")
+	recorder, err := NewRecorder(path, RecordModeRecord)
+	assert.NoError(t, err)
+	client := NewClient(server.URL, WithRecorder(recorder))
+	before, err := client.ReadTask(context.Background(), 1)
+	assert.NoError(t, err)
+	_, err = client.UpdateTask(context.Background(), Task{ID: 1, Title: "Updated task"})
+	assert.NoError(t, err)
+	after, err := client.ReadTask(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.NoError(t, recorder.Save())
+
+	replayer, err := NewRecorder(path, RecordModeReplay)
+	assert.NoError(t, err)
+	client = NewClient(server.URL, WithRecorder(replayer), WithRetryPolicy(RetryPolicy{}))
+
+	// reads are repeated, but only answered with the responses recorded since the last change
+	for range 2 {
+		task, err := client.ReadTask(context.Background(), 1)
+		assert.NoError(t, err)
+		assert.Equal(t, before, task)
+	}
+	_, err = client.UpdateTask(context.Background(), Task{ID: 1, Title: "Updated task"})
+	assert.NoError(t, err)
+	for range 2 {
+		task, err := client.ReadTask(context.Background(), 1)
+		assert.NoError(t, err)
+		assert.Equal(t, after, task)
+	}
+	// changes are not
+	_, err = client.UpdateTask(context.Background(), Task{ID: 1, Title: "Updated task"})
+	assert.ErrorContains(t, err, "no recorded response for PUT")
+}
+
+func TestRecorderMissingCassette(t *testing.T) {
+	_, err := NewRecorder(filepath.Join(t.TempDir(), "missing.json"), RecordModeReplay)
+
+	assert.ErrorContains(t, err, "TASKLITE_RECORD_MODE=record")
+}
+
+func TestRecorderDisabled(t *testing.T) {
+	server, calls := setupCountingServer(t)
+	path := filepath.Join(t.TempDir(), "test.json")
+	recorder, err := NewRecorder(path, RecordModeDisabled)
+	assert.NoError(t, err)
+
+	_, err = NewClient(server.URL, WithRecorder(recorder)).ReadTask(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.NoError(t, recorder.Save())
+
+	assert.Equal(t, int32(1), calls.Load())
+	assert.NoFileExists(t, path)
+}
+
+func TestRecordModeFromEnv(t *testing.T) {
+	t.Setenv(RecordModeEnvVar, "")
+	mode, err := RecordModeFromEnv()
+	assert.NoError(t, err)
+	assert.Equal(t, RecordModeReplay, mode)
+
+	t.Setenv(RecordModeEnvVar, "record")
+	mode, err = RecordModeFromEnv()
+	assert.NoError(t, err)
+	assert.Equal(t, RecordModeRecord, mode)
+
+	t.Setenv(RecordModeEnvVar, "rewind")
+	_, err = RecordModeFromEnv()
+	assert.ErrorContains(t, err, `"rewind"`)
+}
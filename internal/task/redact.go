@@ -0,0 +1,101 @@
+package task
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// redacted replaces secrets in cassettes and logs.
+const redacted = "REDACTED"
+
+// DefaultRedactedHeaders are the headers whose values are not written to cassettes and logs.
+var DefaultRedactedHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+// DefaultRedactedFields are the JSON and form fields whose values are not written to
+// cassettes and logs.
+var DefaultRedactedFields = []string{"password", "token", "access_token", "refresh_token", "id_token", "client_secret"}
+
+// redactor replaces the values of secret headers and body fields.
+type redactor struct {
+	headers []string
+	fields  []string
+}
+
+// header returns a copy of h with the values of the redacted headers replaced.
+func (r redactor) header(h http.Header) http.Header {
+	h = h.Clone()
+	for _, name := range r.headers {
+		if _, ok := h[http.CanonicalHeaderKey(name)]; ok {
+			h.Set(name, redacted)
+		}
+	}
+	return h
+}
+
+// body returns body with the values of the redacted fields replaced, in JSON and
+// form encoded bodies.
+func (r redactor) body(contentType string, body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	if strings.HasPrefix(contentType, "application/x-www-form-urlencoded") {
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			return string(body)
+		}
+		for _, field := range r.fields {
+			if values.Has(field) {
+				values.Set(field, redacted)
+			}
+		}
+		return values.Encode()
+	}
+
+	var v any
+	if json.Unmarshal(body, &v) != nil {
+		return string(body)
+	}
+	if !r.value(v) {
+		return string(body)
+	}
+	redactedBody, err := json.Marshal(v)
+	if err != nil {
+		return string(body)
+	}
+	return string(redactedBody)
+}
+
+// value replaces the redacted fields found in v and reports whether any was found.
+func (r redactor) value(v any) bool {
+	found := false
+	switch v := v.(type) {
+	case map[string]any:
+		for k, field := range v {
+			if r.isRedactedField(k) {
+				v[k] = redacted
+				found = true
+			} else if r.value(field) {
+				found = true
+			}
+		}
+	case []any:
+		for _, item := range v {
+			if r.value(item) {
+				found = true
+			}
+		}
+	}
+	return found
+}
+
+func (r redactor) isRedactedField(name string) bool {
+	for _, field := range r.fields {
+		if strings.EqualFold(field, name) {
+			return true
+		}
+	}
+	return false
+}
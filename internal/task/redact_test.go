@@ -0,0 +1,32 @@
+package task
+
+import (
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactHeader(t *testing.T) {
+	r := redactor{headers: DefaultRedactedHeaders}
+	h := http.Header{"Authorization": {"Bearer secret"}, "Content-Type": {"application/json"}}
+
+	redactedHeader := r.header(h)
+
+	assert.Equal(t, http.Header{"Authorization": {"REDACTED"}, "Content-Type": {"application/json"}}, redactedHeader)
+	assert.Equal(t, "Bearer secret", h.Get("Authorization"))
+}
+
+func TestRedactBody(t *testing.T) {
+	r := redactor{fields: append(slices.Clone(DefaultRedactedFields), "api_key")}
+
+	assert.JSONEq(t,
+		`{"title":"Task","nested":[{"api_key":"REDACTED"}],"password":"REDACTED"}`,
+		r.body("application/json", []byte(`{"title":"Task","nested":[{"api_key":"k"}],"password":"p"}`)))
+	assert.Equal(t,
+		"client_id=id&client_secret=REDACTED&grant_type=client_credentials",
+		r.body("application/x-www-form-urlencoded", []byte("grant_type=client_credentials&client_id=id&client_secret=s")))
+	assert.Equal(t, `{"title": "Task"}`, r.body("application/json", []byte(`{"title": "Task"}`)))
+	assert.Equal(t, "not json", r.body("text/plain", []byte("not json")))
+}
@@ -0,0 +1,119 @@
+package task
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how the client retries failed requests. Only idempotent
+// methods, or requests carrying an Idempotency-Key header, are ever retried.
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt. Zero disables retries.
+	MaxRetries int
+	// BaseBackoff is the wait before the first retry, doubled on every further retry.
+	BaseBackoff time.Duration
+	// MaxBackoff caps the wait between attempts, including waits requested by Retry-After.
+	MaxBackoff time.Duration
+	// Jitter is the fraction, between 0 and 1, of each wait that is randomised.
+	Jitter float64
+}
+
+// DefaultRetryPolicy returns the retry policy used by NewClient.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries:  3,
+		BaseBackoff: 500 * time.Millisecond,
+		MaxBackoff:  30 * time.Second,
+		Jitter:      0.2,
+	}
+}
+
+// WithRetryPolicy sets the retry policy of the client.
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(c *Client) {
+		c.RetryPolicy = p
+	}
+}
+
+// backoff returns the wait before retry number attempt (starting at 0), honouring the
+// Retry-After header of resp when present.
+func (p RetryPolicy) backoff(attempt int, resp *http.Response) time.Duration {
+	wait := p.BaseBackoff << attempt
+	if wait <= 0 || wait > p.MaxBackoff {
+		wait = p.MaxBackoff
+	}
+
+	if p.Jitter > 0 {
+		spread := time.Duration(float64(wait) * p.Jitter)
+		wait = wait - spread + time.Duration(rand.Int64N(int64(spread)+1))
+	}
+
+	if after, ok := retryAfter(resp); ok && after > wait {
+		wait = after
+	}
+
+	if wait > p.MaxBackoff {
+		wait = p.MaxBackoff
+	}
+
+	return wait
+}
+
+// retryAfter parses the Retry-After header of resp, given either in seconds or as an HTTP date.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(v); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if t, err := http.ParseTime(v); err == nil {
+		return time.Until(t), true
+	}
+
+	return 0, false
+}
+
+// isRetryable reports whether req may safely be sent again.
+func isRetryable(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	case http.MethodPatch:
+		// applying the same JSON Merge Patch twice has the same effect as applying it once
+		return req.Header.Get("Content-Type") == MergePatchContentType
+	}
+	return req.Header.Get(IdempotencyKeyHeader) != ""
+}
+
+// shouldRetry reports whether the outcome of an attempt is a transient failure.
+func shouldRetry(ctx context.Context, resp *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if err != nil {
+		// a server certificate failing verification will not be fixed by retrying
+		var certErr *tls.CertificateVerificationError
+		return !errors.As(err, &certErr)
+	}
+	return isRetryableStatus(resp.StatusCode)
+}
+
+// drainAndClose discards the rest of the body so the connection can be reused.
+func drainAndClose(resp *http.Response) {
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+	_ = resp.Body.Close()
+}
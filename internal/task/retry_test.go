@@ -0,0 +1,124 @@
+package task
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testRetryPolicy = RetryPolicy{
+	MaxRetries:  3,
+	BaseBackoff: time.Millisecond,
+	MaxBackoff:  10 * time.Millisecond,
+}
+
+// setupFlakyServer fails the first failures requests with status before serving response.
+func setupFlakyServer(t *testing.T, failures int32, status int, response string) (*httptest.Server, *atomic.Int32) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= failures {
+			if status == 0 {
+				// simulate a connection reset
+				conn, _, err := w.(http.Hijacker).Hijack()
+				if err != nil {
+					t.Fatal(err)
+				}
+				_ = conn.Close()
+				return
+			}
+			w.WriteHeader(status)
+			return
+		}
+		_, _ = w.Write([]byte(response))
+	}))
+	return server, &calls
+}
+
+func TestRetryIdempotentRequest(t *testing.T) {
+	server, calls := setupFlakyServer(t, 2, http.StatusServiceUnavailable, `{"id":1,"title":"Test Task"}`)
+	defer server.Close()
+
+	task, err := NewClient(server.URL, WithRetryPolicy(testRetryPolicy)).ReadTask(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &Task{ID: 1, Title: "Test Task"}, task)
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestRetryPatchRequest(t *testing.T) {
+	server, calls := setupFlakyServer(t, 1, http.StatusBadGateway, `{"id":1,"title":"Test Task"}`)
+	defer server.Close()
+
+	_, err := NewClient(server.URL, WithRetryPolicy(testRetryPolicy)).PatchTask(context.Background(), 1, map[string]any{"title": "Test Task"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestRetryConnectionReset(t *testing.T) {
+	server, calls := setupFlakyServer(t, 1, 0, `{"id":1,"title":"Test Task"}`)
+	defer server.Close()
+
+	_, err := NewClient(server.URL, WithRetryPolicy(testRetryPolicy)).ReadTask(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	server, calls := setupFlakyServer(t, 10, http.StatusBadGateway, `{}`)
+	defer server.Close()
+
+	_, err := NewClient(server.URL, WithRetryPolicy(testRetryPolicy)).ReadTask(context.Background(), 1)
+
+	assert.True(t, IsRetryable(err))
+	assert.Equal(t, int32(4), calls.Load())
+}
+
+func TestRetrySkipsNonIdempotentRequest(t *testing.T) {
+	server, calls := setupFlakyServer(t, 1, http.StatusServiceUnavailable, `{"id":1,"title":"Test Task"}`)
+	defer server.Close()
+
+	resp, err := NewClient(server.URL, WithRetryPolicy(testRetryPolicy)).doRequest(context.Background(), http.MethodPost, TASK_URI, Task{Title: "Test Task"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestRetrySkipsClientError(t *testing.T) {
+	server, calls := setupFlakyServer(t, 1, http.StatusBadRequest, `{}`)
+	defer server.Close()
+
+	_, err := NewClient(server.URL, WithRetryPolicy(testRetryPolicy)).ReadTask(context.Background(), 1)
+
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	p := RetryPolicy{BaseBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+
+	assert.Equal(t, 100*time.Millisecond, p.backoff(0, nil))
+	assert.Equal(t, 400*time.Millisecond, p.backoff(2, nil))
+	assert.Equal(t, time.Second, p.backoff(10, nil))
+	assert.Equal(t, time.Second, p.backoff(100, nil))
+
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"1"}}}
+	assert.Equal(t, time.Second, p.backoff(0, resp))
+
+	resp.Header.Set("Retry-After", "120")
+	assert.Equal(t, time.Second, p.backoff(0, resp))
+
+	p.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		wait := p.backoff(1, nil)
+		assert.GreaterOrEqual(t, wait, 100*time.Millisecond)
+		assert.LessOrEqual(t, wait, 200*time.Millisecond)
+	}
+}
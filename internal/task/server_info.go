@@ -0,0 +1,89 @@
+package task
+
+import (
+	"context"
+	"net/http"
+	"slices"
+)
+
+// VERSION_URI serves the API version and capabilities of the server.
+const VERSION_URI = "/api/version/"
+
+// Capability is an optional feature of the TaskLite API.
+type Capability string
+
+// Capabilities advertised by TaskLite servers.
+const (
+	CapabilityIdempotencyKeys Capability = "idempotency_keys"
+	CapabilityETags           Capability = "etags"
+	CapabilityPatch           Capability = "patch"
+)
+
+// ServerInfo describes the TaskLite server. Servers predating version discovery have an
+// empty Version and no capabilities.
+type ServerInfo struct {
+	Version      string       `json:"version"`
+	Capabilities []Capability `json:"capabilities"`
+}
+
+// Legacy reports whether the server predates version discovery.
+func (i *ServerInfo) Legacy() bool {
+	return i.Version == ""
+}
+
+// Probe checks that the server is reachable and accepts the client credentials, and
+// detects its API version and capabilities. The result is kept by the client and
+// returned by ServerInfo.
+func (c *Client) Probe(ctx context.Context) (*ServerInfo, error) {
+	resp, err := c.doRequest(ctx, http.MethodGet, VERSION_URI, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &ServerInfo{}
+	if resp.StatusCode == http.StatusNotFound {
+		// the server answered, but does not support version discovery
+		drainAndClose(resp)
+	} else if err := c.parseResponse(resp, info); err != nil {
+		return nil, err
+	}
+
+	c.serverInfo.Store(info)
+	return info, nil
+}
+
+// ServerInfo returns the information detected by Probe, or nil when the server was not
+// probed.
+func (c *Client) ServerInfo() *ServerInfo {
+	return c.serverInfo.Load()
+}
+
+// Support is whether a server supports a capability.
+type Support int
+
+const (
+	// SupportUnknown is the support of every capability by servers that were not probed,
+	// and by legacy servers, which do not advertise their capabilities.
+	SupportUnknown Support = iota
+	// Supported capabilities are advertised by the server.
+	Supported
+	// Unsupported capabilities are not advertised by a server that advertises its
+	// capabilities.
+	Unsupported
+)
+
+// Support reports whether the server is known to support capability. Callers decide how
+// to handle SupportUnknown: a request may still use a capability whose absence makes the
+// server fail, such as PATCH, but must not rely on one the server would silently ignore,
+// such as idempotency keys.
+func (c *Client) Support(capability Capability) Support {
+	info := c.ServerInfo()
+	switch {
+	case info == nil || info.Legacy():
+		return SupportUnknown
+	case slices.Contains(info.Capabilities, capability):
+		return Supported
+	default:
+		return Unsupported
+	}
+}
@@ -0,0 +1,48 @@
+package task
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProbe(t *testing.T) {
+	server := setupTestServer(t, http.MethodGet, ServerInfo{Version: "2.1.0", Capabilities: []Capability{CapabilityIdempotencyKeys}}, http.StatusOK)
+	defer server.Close()
+	client := NewClient(server.URL)
+	assert.Nil(t, client.ServerInfo())
+	assert.Equal(t, SupportUnknown, client.Support(CapabilityPatch))
+
+	info, err := client.Probe(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, &ServerInfo{Version: "2.1.0", Capabilities: []Capability{CapabilityIdempotencyKeys}}, info)
+	assert.Equal(t, info, client.ServerInfo())
+	assert.Equal(t, Supported, client.Support(CapabilityIdempotencyKeys))
+	assert.Equal(t, Unsupported, client.Support(CapabilityPatch))
+}
+
+func TestProbeLegacyServer(t *testing.T) {
+	server := setupTestServer(t, http.MethodGet, nil, http.StatusNotFound)
+	defer server.Close()
+	client := NewClient(server.URL)
+
+	info, err := client.Probe(context.Background())
+
+	assert.NoError(t, err)
+	assert.True(t, info.Legacy())
+	assert.Equal(t, SupportUnknown, client.Support(CapabilityIdempotencyKeys))
+}
+
+func TestProbeError(t *testing.T) {
+	server := setupTestServer(t, http.MethodGet, map[string]string{"message": "invalid token"}, http.StatusUnauthorized)
+	defer server.Close()
+	client := NewClient(server.URL)
+
+	_, err := client.Probe(context.Background())
+
+	assert.EqualError(t, err, "GET "+server.URL+VERSION_URI+": HTTP 401: invalid token")
+	assert.Nil(t, client.ServerInfo())
+}
@@ -4,9 +4,17 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
 	"net/http"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type Task struct {
@@ -14,50 +22,148 @@ type Task struct {
 	Title    string `json:"title"`
 	Complete bool   `json:"complete"`
 	Priority int32  `json:"priority"`
+	// ETag is the version of the task returned by the server, if any.
+	ETag string `json:"-"`
 }
 
 type ClientInterface interface {
+	ListTasks(ctx context.Context) ([]Task, error)
 	CreateTask(ctx context.Context, t Task) (*Task, error)
 	ReadTask(ctx context.Context, id int32) (*Task, error)
 	UpdateTask(ctx context.Context, t Task) (*Task, error)
+	PatchTask(ctx context.Context, id int32, fields map[string]any) (*Task, error)
 	DeleteTask(ctx context.Context, id int32) error
+	Support(capability Capability) Support
 }
 
 type Client struct {
-	BaseURL    string
-	HTTPClient *http.Client
+	BaseURL     string
+	HTTPClient  *http.Client
+	RetryPolicy RetryPolicy
+
+	authenticators    []Authenticator
+	recorder          *Recorder
+	redactedHeaders   []string
+	redactedLogFields []string
+	limiter           *rateLimiter
+	breaker           *circuitBreaker
+	failoverURLs      []string
+	endpoints         *endpointPool
+	serverInfo        atomic.Pointer[ServerInfo]
+	tracer            trace.Tracer
+	metrics           Metrics
 }
 
-func NewClient(baseURL string) *Client {
-	return &Client{
-		BaseURL:    baseURL,
-		HTTPClient: &http.Client{},
+// Option configures optional behaviour of a Client.
+type Option func(*Client)
+
+// NewClient returns a client for the TaskLite API at baseURL. The rate limit and the
+// concurrency cap are shared by all users of the returned client.
+func NewClient(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		BaseURL:     baseURL,
+		HTTPClient:  &http.Client{Timeout: DefaultRequestTimeout},
+		RetryPolicy: DefaultRetryPolicy(),
+		limiter:     &rateLimiter{},
+		breaker:     &circuitBreaker{threshold: DefaultCircuitBreakerThreshold, cooldown: DefaultCircuitBreakerCooldown},
+		endpoints:   &endpointPool{healthCheckPath: DefaultHealthCheckPath, interval: healthCheckInterval},
+		tracer:      defaultTracer(),
+		metrics:     noMetrics{},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.recorder != nil {
+		c.HTTPClient.Transport = &recordingTransport{recorder: c.recorder, base: c.HTTPClient.Transport, redactedHeaders: c.redactedHeaders}
+	}
+	// requests are logged after authentication, so that the credentials are seen and redacted
+	c.HTTPClient.Transport = &loggingTransport{
+		base: c.HTTPClient.Transport,
+		redactor: redactor{
+			headers: append(slices.Clone(DefaultRedactedHeaders), c.redactedHeaders...),
+			fields:  append(slices.Clone(DefaultRedactedFields), c.redactedLogFields...),
+		},
+	}
+	if len(c.authenticators) > 0 {
+		base := c.HTTPClient.Transport
+		if base == nil {
+			base = http.DefaultTransport
+		}
+		for _, a := range c.authenticators {
+			if s, ok := a.(transportSetter); ok {
+				s.setTransport(base)
+			}
+		}
+		c.HTTPClient.Transport = &authTransport{base: base, authenticators: c.authenticators}
 	}
+	c.limiter.maxPause = c.RetryPolicy.MaxBackoff
+	c.endpoints.httpClient = c.HTTPClient
+	c.endpoints.setEndpoints(append([]string{c.BaseURL}, c.failoverURLs...))
+
+	return c
 }
 
 const TASK_URI = "/api/task/"
 
-func apiPath(baseURL string) string {
-	return fmt.Sprintf("%s%s", baseURL, TASK_URI)
+// MergePatchContentType is the media type of the JSON Merge Patch documents sent by PatchTask.
+const MergePatchContentType = "application/merge-patch+json"
+
+// taskPath returns the path of the task with the given id.
+func taskPath(id int32) string {
+	return fmt.Sprintf("%s%d/", TASK_URI, id)
+}
+
+func (c *Client) ListTasks(ctx context.Context) ([]Task, error) {
+	resp, err := c.doRequest(ctx, http.MethodGet, TASK_URI, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var tt []Task
+	if err := c.parseResponse(resp, &tt); err != nil {
+		return nil, err
+	}
+
+	return tt, nil
 }
 
+// CreateTask creates t. When the server is known to support idempotency keys, the
+// request carries the Idempotency-Key set with ContextWithIdempotencyKey, or a new random
+// key, so that it can be retried safely. Otherwise, it carries none and is never resent:
+// a server ignoring the key would create a duplicate task.
 func (c *Client) CreateTask(ctx context.Context, t Task) (*Task, error) {
-	resp, err := c.doRequest(ctx, http.MethodPost, apiPath(c.BaseURL), t)
+	var key string
+	if c.Support(CapabilityIdempotencyKeys) == Supported {
+		if key = idempotencyKeyFromContext(ctx); key == "" {
+			var err error
+			if key, err = NewIdempotencyKey(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	ctx = ContextWithIdempotencyKey(ctx, key)
+
+	resp, err := c.doRequest(ctx, http.MethodPost, TASK_URI, t)
 	if err != nil {
 		return nil, err
 	}
 
+	if resp.Header.Get(IdempotentReplayedHeader) == "true" {
+		tflog.Info(ctx, "TaskLite replayed the response of an earlier create request", map[string]any{"idempotency_key": key})
+	}
+
 	var tt Task
 	if err := c.parseResponse(resp, &tt); err != nil {
 		return nil, err
 	}
+	tt.ETag = resp.Header.Get(ETagHeader)
 
 	return &tt, nil
 }
 
 func (c *Client) ReadTask(ctx context.Context, id int32) (*Task, error) {
-	url := fmt.Sprintf("%s%d/", apiPath(c.BaseURL), id)
-	resp, err := c.doRequest(ctx, http.MethodGet, url, nil)
+	resp, err := c.doRequest(ctx, http.MethodGet, taskPath(id), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -66,14 +172,15 @@ func (c *Client) ReadTask(ctx context.Context, id int32) (*Task, error) {
 	if err := c.parseResponse(resp, &t); err != nil {
 		return nil, err
 	}
+	t.ETag = resp.Header.Get(ETagHeader)
 
 	return &t, nil
 }
 
+// UpdateTask replaces t. With ContextWithIfMatch, it fails with ErrPreconditionFailed when
+// the task changed since it was read.
 func (c *Client) UpdateTask(ctx context.Context, t Task) (*Task, error) {
-	url := fmt.Sprintf("%s%d/", apiPath(c.BaseURL), t.ID)
-
-	resp, err := c.doRequest(ctx, http.MethodPut, url, t)
+	resp, err := c.doRequest(ctx, http.MethodPut, taskPath(t.ID), t)
 	if err != nil {
 		return nil, err
 	}
@@ -82,28 +189,57 @@ func (c *Client) UpdateTask(ctx context.Context, t Task) (*Task, error) {
 	if err := c.parseResponse(resp, &tt); err != nil {
 		return nil, err
 	}
+	tt.ETag = resp.Header.Get(ETagHeader)
 
 	return &tt, nil
 }
 
+// PatchTask changes the given fields of the task with the given id, leaving the others
+// as they are. fields is sent as a JSON Merge Patch (RFC 7396), keyed by the JSON names of
+// the Task fields. With ContextWithIfMatch, it fails with ErrPreconditionFailed when the
+// task changed since it was read.
+func (c *Client) PatchTask(ctx context.Context, id int32, fields map[string]any) (*Task, error) {
+	resp, err := c.doRequest(ctx, http.MethodPatch, taskPath(id), fields)
+	if err != nil {
+		return nil, err
+	}
+
+	var t Task
+	if err := c.parseResponse(resp, &t); err != nil {
+		return nil, err
+	}
+	t.ETag = resp.Header.Get(ETagHeader)
+
+	return &t, nil
+}
+
+// DeleteTask deletes the task with the given id. Any 2xx status is a success, whether or
+// not the server sends a body. With ContextWithIfMatch, it fails with
+// ErrPreconditionFailed when the task changed since it was read.
 func (c *Client) DeleteTask(ctx context.Context, id int32) error {
-	url := fmt.Sprintf("%s%d/", apiPath(c.BaseURL), id)
-	resp, err := c.doRequest(ctx, http.MethodDelete, url, nil)
+	resp, err := c.doRequest(ctx, http.MethodDelete, taskPath(id), nil)
 
 	if err != nil {
 		return err
 	}
+	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("failed to delete task: %s", resp.Status)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return newAPIError(resp)
 	}
 
 	return nil
 }
 
-func (c *Client) doRequest(ctx context.Context, method, url string, body interface{}) (*http.Response, error) {
+// doRequest sends a request for path, relative to the endpoint base URL. Requests that
+// may safely be sent again fail over to the next endpoint when an endpoint fails, and are
+// retried according to the retry policy once all endpoints failed. Each request is traced
+// with a span covering all its attempts.
+func (c *Client) doRequest(ctx context.Context, method, path string, body interface{}) (resp *http.Response, err error) {
+	ctx, span := c.tracer.Start(ctx, spanName(method, path), trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrHTTPMethod.String(method)))
+	defer func() { endRequestSpan(span, resp, err) }()
+
 	var reqBody []byte
-	var err error
 	if body != nil {
 		reqBody, err = json.Marshal(body)
 		if err != nil {
@@ -111,26 +247,97 @@ func (c *Client) doRequest(ctx context.Context, method, url string, body interfa
 		}
 	}
 
-	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewBuffer(reqBody))
+	start := time.Now()
+	for attempt := 0; ; attempt++ {
+		var req *http.Request
+		var resp *http.Response
+		endpoints := c.endpoints.order(ctx)
+		for i, e := range endpoints {
+			req, resp, err = c.send(ctx, method, e.baseURL+path, reqBody, start)
+			if req == nil || errors.Is(err, ErrCircuitOpen) {
+				return nil, err
+			}
+			span.SetAttributes(attrURLFull.String(req.URL.String()))
+			if !c.endpoints.report(ctx, e, resp, err) || i == len(endpoints)-1 || !isRetryable(req) {
+				break
+			}
+			if resp != nil {
+				drainAndClose(resp)
+			}
+			tflog.Debug(ctx, "Failing over to the next TaskLite endpoint", map[string]any{"method": method, "url": req.URL.String()})
+		}
+
+		if attempt >= c.RetryPolicy.MaxRetries || !isRetryable(req) || !shouldRetry(ctx, resp, err) {
+			return resp, err
+		}
+
+		wait := c.RetryPolicy.backoff(attempt, resp)
+		fields := map[string]any{"method": method, "url": req.URL.String(), "attempt": attempt + 1, "wait": wait.String()}
+		if err != nil {
+			fields["error"] = err.Error()
+		} else {
+			fields["status"] = resp.StatusCode
+			drainAndClose(resp)
+		}
+		tflog.Debug(ctx, "Retrying TaskLite request", fields)
+		span.AddEvent("retry", trace.WithAttributes(attribute.Int("attempt", attempt+1), attribute.String("wait", wait.String())))
+		span.SetAttributes(attrHTTPResendCount.Int(attempt + 1))
+		c.metrics.ObserveRetry(method)
+
+		select {
+		case <-ctx.Done():
+			return nil, asTimeoutError(method, req.URL.String(), start, ctx.Err())
+		case <-time.After(wait):
+		}
+	}
+}
+
+// send makes a single attempt of a request. The returned request is nil when it could not
+// be created.
+func (c *Client) send(ctx context.Context, method, url string, body []byte, start time.Time) (*http.Request, *http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	req.Header.Set("Content-Type", "application/json")
+	if method == http.MethodPatch {
+		req.Header.Set("Content-Type", MergePatchContentType)
+	}
+	if key := idempotencyKeyFromContext(ctx); key != "" && method == http.MethodPost {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
+	if etag := ifMatchFromContext(ctx); etag != "" && isConditional(method) {
+		req.Header.Set(IfMatchHeader, etag)
+	}
+	traceContext.Inject(ctx, propagation.HeaderCarrier(req.Header))
 
+	// waiting for the rate limit does not count against the request timeout
+	release, err := c.limiter.acquire(ctx)
+	if err != nil {
+		return req, nil, asTimeoutError(method, url, start, err)
+	}
+	// the breaker is checked after waiting, so that queued requests see failures of
+	// the requests before them
+	if err := c.breaker.allow(ctx); err != nil {
+		release()
+		return req, nil, err
+	}
+	sent := time.Now()
 	resp, err := c.HTTPClient.Do(req)
+	c.metrics.ObserveRequest(method, responseStatus(resp), time.Since(sent))
+	c.limiter.done(ctx, resp, release)
+	c.breaker.record(ctx, resp, err)
 	if err != nil {
-		return nil, err
+		err = asTimeoutError(method, url, start, err)
 	}
-
-	return resp, nil
+	return req, resp, err
 }
 
 func (c *Client) parseResponse(resp *http.Response, out interface{}) error {
 	defer resp.Body.Close()
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))
+		return newAPIError(resp)
 	}
 
 	return json.NewDecoder(resp.Body).Decode(out)
@@ -38,8 +38,8 @@ func TestParseResponseError(t *testing.T) {
 	var task Task
 	err := c.parseResponse(resp, &task)
 	assert.Error(t, err)
-	e := fmt.Errorf("HTTP %d: %s", http.StatusBadRequest, "Bad Request")
-	assert.Equal(t, e, err)
+	e := fmt.Sprintf("GET %s: HTTP %d: %s", server.URL, http.StatusBadRequest, "Bad Request")
+	assert.EqualError(t, err, e)
 }
 
 func TestParseResponseSuccess(t *testing.T) {
@@ -65,6 +65,21 @@ func TestParseResponseSuccess(t *testing.T) {
 	}, task)
 }
 
+func TestListTasks(t *testing.T) {
+	tasksResponse := []Task{
+		{ID: 1, Title: "First Task", Priority: 0, Complete: false},
+		{ID: 2, Title: "Second Task", Priority: 3, Complete: true},
+	}
+
+	server := setupTestServer(t, http.MethodGet, tasksResponse, http.StatusOK)
+	defer server.Close()
+
+	tasks, err := (NewClient(server.URL)).ListTasks(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, tasksResponse, tasks)
+}
+
 func TestCreateTask(t *testing.T) {
 	task := Task{Title: "Test Task"}
 	taskResponse := Task{ID: 1, Title: "Test Task", Priority: 0, Complete: false}
@@ -107,6 +122,24 @@ func TestUpdateTask(t *testing.T) {
 	assert.Equal(t, *updatedTask, taskResponse)
 }
 
+func TestPatchTask(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPatch, r.Method)
+		assert.Equal(t, "/api/task/1/", r.URL.Path)
+		assert.Equal(t, MergePatchContentType, r.Header.Get("Content-Type"))
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"complete":true}`, string(body))
+		w.Header().Set(ETagHeader, `"v2"`)
+		_ = json.NewEncoder(w).Encode(Task{ID: 1, Title: "Test Task", Priority: 3, Complete: true})
+	}))
+	defer server.Close()
+
+	task, err := NewClient(server.URL).PatchTask(context.Background(), 1, map[string]any{"complete": true})
+
+	assert.NoError(t, err)
+	assert.Equal(t, &Task{ID: 1, Title: "Test Task", Priority: 3, Complete: true, ETag: `"v2"`}, task)
+}
+
 func TestDeleteTask(t *testing.T) {
 	server := setupTestServer(t, http.MethodDelete, nil, http.StatusNoContent)
 	defer server.Close()
@@ -114,3 +147,29 @@ func TestDeleteTask(t *testing.T) {
 	err := (NewClient(server.URL)).DeleteTask(context.Background(), 1)
 	assert.NoError(t, err)
 }
+
+func TestDeleteTaskOK(t *testing.T) {
+	server := setupTestServer(t, http.MethodDelete, &Task{ID: 1, Title: "Deleted task"}, http.StatusOK)
+	defer server.Close()
+
+	err := (NewClient(server.URL)).DeleteTask(context.Background(), 1)
+	assert.NoError(t, err)
+}
+
+func TestReadTaskNotFound(t *testing.T) {
+	server := setupTestServer(t, http.MethodGet, nil, http.StatusNotFound)
+	defer server.Close()
+
+	task, err := (NewClient(server.URL)).ReadTask(context.Background(), 1)
+
+	assert.Nil(t, task)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestDeleteTaskNotFound(t *testing.T) {
+	server := setupTestServer(t, http.MethodDelete, nil, http.StatusNotFound)
+	defer server.Close()
+
+	err := (NewClient(server.URL)).DeleteTask(context.Background(), 1)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
@@ -0,0 +1,24 @@
+// Package tasklitetest serves the fake TaskLite API of the fakeserver package in tests.
+package tasklitetest
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"terraform-provider-tasklite/internal/task/fakeserver"
+)
+
+// Server is an API served over HTTP.
+type Server struct {
+	*fakeserver.API
+	*httptest.Server
+}
+
+// NewServer starts a server for a new API, closed when tb and its subtests complete.
+func NewServer(tb testing.TB) *Server {
+	tb.Helper()
+	api := fakeserver.NewAPI()
+	s := &Server{API: api, Server: httptest.NewServer(api)}
+	tb.Cleanup(s.Close)
+	return s
+}
@@ -0,0 +1,24 @@
+package tasklitetest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"terraform-provider-tasklite/internal/task"
+)
+
+func TestNewServer(t *testing.T) {
+	var server *Server
+	t.Run("test", func(t *testing.T) {
+		server = NewServer(t)
+		_, err := task.NewClient(server.URL).CreateTask(context.Background(), task.Task{Title: "Task"})
+		assert.NoError(t, err)
+		assert.Len(t, server.Tasks(), 1)
+	})
+
+	// the server is closed with the test
+	_, err := task.NewClient(server.URL, task.WithRetryPolicy(task.RetryPolicy{})).ListTasks(context.Background())
+	assert.Error(t, err)
+}
@@ -0,0 +1,46 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+)
+
+// DefaultRequestTimeout is the default limit for a single attempt of a request.
+const DefaultRequestTimeout = 60 * time.Second
+
+// TimeoutError is returned when a request does not complete in time, because either the
+// context deadline passed or an attempt exceeded the client request timeout.
+type TimeoutError struct {
+	Method  string
+	URL     string
+	Elapsed time.Duration
+	Err     error
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("%s %s timed out after %s: %s", e.Method, e.URL, e.Elapsed.Round(time.Millisecond), e.Err)
+}
+
+func (e *TimeoutError) Unwrap() error {
+	return e.Err
+}
+
+// WithRequestTimeout limits the duration of each attempt of a request, including reading
+// the response body. Zero means no limit.
+func WithRequestTimeout(d time.Duration) Option {
+	return func(c *Client) {
+		c.HTTPClient.Timeout = d
+	}
+}
+
+// asTimeoutError wraps err in a TimeoutError when it was caused by a deadline.
+func asTimeoutError(method, url string, start time.Time, err error) error {
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return &TimeoutError{Method: method, URL: url, Elapsed: time.Since(start), Err: err}
+	}
+	return err
+}
@@ -0,0 +1,52 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func setupSlowServer(delay time.Duration) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(delay):
+		case <-r.Context().Done():
+		}
+		_, _ = w.Write([]byte(`{"id":1,"title":"Test Task"}`))
+	}))
+}
+
+func TestContextDeadline(t *testing.T) {
+	server := setupSlowServer(time.Second)
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := NewClient(server.URL).ReadTask(ctx, 1)
+
+	var timeoutErr *TimeoutError
+	assert.True(t, errors.As(err, &timeoutErr))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, http.MethodGet, timeoutErr.Method)
+	assert.GreaterOrEqual(t, timeoutErr.Elapsed, 50*time.Millisecond)
+	assert.Contains(t, err.Error(), "timed out after")
+}
+
+func TestRequestTimeoutIsRetried(t *testing.T) {
+	server := setupSlowServer(time.Second)
+	defer server.Close()
+
+	start := time.Now()
+	_, err := NewClient(server.URL, WithRetryPolicy(testRetryPolicy), WithRequestTimeout(20*time.Millisecond)).ReadTask(context.Background(), 1)
+
+	var timeoutErr *TimeoutError
+	assert.True(t, errors.As(err, &timeoutErr))
+	// the first attempt and 3 retries each time out
+	assert.GreaterOrEqual(t, time.Since(start), 80*time.Millisecond)
+	assert.Less(t, time.Since(start), time.Second)
+}
@@ -0,0 +1,64 @@
+package task
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// TLSOptions describes how the client verifies the server and authenticates itself over TLS.
+type TLSOptions struct {
+	// CACertPEM holds PEM encoded certificates trusted in addition to the system pool.
+	CACertPEM []byte
+	// ClientCertPEM and ClientKeyPEM hold the PEM encoded client certificate and key for mutual TLS.
+	ClientCertPEM []byte
+	ClientKeyPEM  []byte
+	// ServerName overrides the name used to verify the server certificate.
+	ServerName string
+	// InsecureSkipVerify disables verification of the server certificate.
+	InsecureSkipVerify bool
+}
+
+// Config builds the tls.Config described by o.
+func (o TLSOptions) Config() (*tls.Config, error) {
+	cfg := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         o.ServerName,
+		InsecureSkipVerify: o.InsecureSkipVerify,
+	}
+
+	if len(o.CACertPEM) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(o.CACertPEM) {
+			return nil, errors.New("no valid PEM encoded certificate found in the CA bundle")
+		}
+		cfg.RootCAs = pool
+	}
+
+	if len(o.ClientCertPEM) > 0 || len(o.ClientKeyPEM) > 0 {
+		cert, err := tls.X509KeyPair(o.ClientCertPEM, o.ClientKeyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate or key: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return cfg, nil
+}
+
+// WithTLSConfig makes the client use cfg for HTTPS connections.
+func WithTLSConfig(cfg *tls.Config) Option {
+	return func(c *Client) {
+		transport := &http.Transport{Proxy: http.ProxyFromEnvironment}
+		if t, ok := http.DefaultTransport.(*http.Transport); ok {
+			transport = t.Clone()
+		}
+		transport.TLSClientConfig = cfg
+		c.HTTPClient.Transport = transport
+	}
+}
@@ -0,0 +1,146 @@
+package task
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testCert struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	certPEM []byte
+	keyPEM  []byte
+}
+
+// newTestCert generates a certificate for template, signed by parent or self-signed when parent is nil.
+func newTestCert(t *testing.T, template *x509.Certificate, parent *testCert) testCert {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template.SerialNumber = big.NewInt(time.Now().UnixNano())
+	template.NotBefore = time.Now().Add(-time.Hour)
+	template.NotAfter = time.Now().Add(time.Hour)
+
+	signerCert, signerKey := template, key
+	if parent != nil {
+		signerCert, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, signerCert, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+	keyDER, _ := x509.MarshalECPrivateKey(key)
+
+	return testCert{
+		cert:    cert,
+		key:     key,
+		certPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		keyPEM:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	}
+}
+
+// setupTLSServer starts a server with a certificate for tasklite.internal signed by a
+// private CA, requiring client certificates signed by the same CA.
+func setupTLSServer(t *testing.T) (*httptest.Server, testCert) {
+	ca := newTestCert(t, &x509.Certificate{
+		Subject:               pkix.Name{CommonName: "TaskLite Test CA"},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, nil)
+	serverCert := newTestCert(t, &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "tasklite.internal"},
+		DNSNames:    []string{"tasklite.internal"},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}, &ca)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id":1,"title":"Test Task"}`))
+	}))
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(ca.cert)
+	server.TLS = &tls.Config{
+		Certificates: []tls.Certificate{{Certificate: [][]byte{serverCert.cert.Raw}, PrivateKey: serverCert.key}},
+		ClientCAs:    clientCAs,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+	}
+	server.StartTLS()
+	return server, ca
+}
+
+func TestTLSConfigMutualTLS(t *testing.T) {
+	server, ca := setupTLSServer(t)
+	defer server.Close()
+	clientCert := newTestCert(t, &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "terraform"},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}, &ca)
+
+	cfg, err := TLSOptions{
+		CACertPEM:     ca.certPEM,
+		ClientCertPEM: clientCert.certPEM,
+		ClientKeyPEM:  clientCert.keyPEM,
+		ServerName:    "tasklite.internal",
+	}.Config()
+	assert.NoError(t, err)
+
+	task, err := NewClient(server.URL, WithTLSConfig(cfg)).ReadTask(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, &Task{ID: 1, Title: "Test Task"}, task)
+}
+
+func TestTLSConfigUntrustedServer(t *testing.T) {
+	server, ca := setupTLSServer(t)
+	defer server.Close()
+
+	cfg, err := TLSOptions{ServerName: "tasklite.internal"}.Config()
+	assert.NoError(t, err)
+
+	_, err = NewClient(server.URL, WithRetryPolicy(RetryPolicy{}), WithTLSConfig(cfg)).ReadTask(context.Background(), 1)
+	var unknownAuthority x509.UnknownAuthorityError
+	assert.ErrorAs(t, err, &unknownAuthority)
+
+	// the CA is trusted but the server name does not match the certificate
+	cfg, err = TLSOptions{CACertPEM: ca.certPEM}.Config()
+	assert.NoError(t, err)
+
+	_, err = NewClient(server.URL, WithRetryPolicy(RetryPolicy{}), WithTLSConfig(cfg)).ReadTask(context.Background(), 1)
+	var hostnameErr x509.HostnameError
+	assert.ErrorAs(t, err, &hostnameErr)
+}
+
+func TestTLSConfigInsecureSkipVerify(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id":1,"title":"Test Task"}`))
+	}))
+	defer server.Close()
+
+	cfg, err := TLSOptions{InsecureSkipVerify: true}.Config()
+	assert.NoError(t, err)
+
+	_, err = NewClient(server.URL, WithTLSConfig(cfg)).ReadTask(context.Background(), 1)
+	assert.NoError(t, err)
+}
+
+func TestTLSOptionsInvalid(t *testing.T) {
+	_, err := TLSOptions{CACertPEM: []byte("not a certificate")}.Config()
+	assert.EqualError(t, err, "no valid PEM encoded certificate found in the CA bundle")
+
+	_, err = TLSOptions{ClientCertPEM: []byte("not a certificate")}.Config()
+	assert.ErrorContains(t, err, "invalid client certificate or key")
+}
@@ -0,0 +1,64 @@
+package task
+
+import (
+	"net/http"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName identifies the spans of the client.
+const tracerName = "terraform-provider-tasklite/internal/task"
+
+// Attributes of the request spans, following the OpenTelemetry HTTP semantic conventions.
+const (
+	attrHTTPMethod      = attribute.Key("http.request.method")
+	attrHTTPStatusCode  = attribute.Key("http.response.status_code")
+	attrHTTPResendCount = attribute.Key("http.request.resend_count")
+	attrURLFull         = attribute.Key("url.full")
+)
+
+// traceContext propagates the span of each request in the W3C traceparent header.
+var traceContext = propagation.TraceContext{}
+
+// WithTracerProvider makes the client trace its requests with tp instead of the global
+// tracer provider.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *Client) {
+		c.tracer = tp.Tracer(tracerName)
+	}
+}
+
+// defaultTracer returns the tracer of the global tracer provider, which forwards to the
+// provider registered with otel.SetTracerProvider, even later.
+func defaultTracer() trace.Tracer {
+	return otel.GetTracerProvider().Tracer(tracerName)
+}
+
+// spanName returns the name of the span of a request for path, with task IDs replaced so
+// that names do not grow with the number of tasks.
+func spanName(method, path string) string {
+	if path != TASK_URI && strings.HasPrefix(path, TASK_URI) {
+		path = TASK_URI + "{id}/"
+	}
+	return method + " " + path
+}
+
+// endRequestSpan records the outcome of a request on span and ends it.
+func endRequestSpan(span trace.Span, resp *http.Response, err error) {
+	defer span.End()
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return
+	}
+	span.SetAttributes(attrHTTPStatusCode.Int(resp.StatusCode))
+	if resp.StatusCode >= 400 {
+		span.SetStatus(codes.Error, resp.Status)
+	}
+}
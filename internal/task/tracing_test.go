@@ -0,0 +1,89 @@
+package task
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// newSpanRecorder returns a tracer provider keeping the ended spans in the returned recorder.
+func newSpanRecorder() (*sdktrace.TracerProvider, *tracetest.SpanRecorder) {
+	recorder := tracetest.NewSpanRecorder()
+	return sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)), recorder
+}
+
+func spanAttributes(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	attrs := map[attribute.Key]attribute.Value{}
+	for _, kv := range span.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
+
+func TestRequestSpan(t *testing.T) {
+	var calls atomic.Int32
+	var traceparent atomic.Value
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent.Store(r.Header.Get("traceparent"))
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":1,"title":"Test Task"}`))
+	}))
+	defer server.Close()
+	tp, recorder := newSpanRecorder()
+	client := NewClient(server.URL, WithTracerProvider(tp), WithRetryPolicy(testRetryPolicy))
+
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "parent")
+	_, err := client.ReadTask(ctx, 1)
+	parent.End()
+
+	assert.NoError(t, err)
+	spans := recorder.Ended()
+	assert.Len(t, spans, 2)
+	span := spans[0]
+	assert.Equal(t, "GET /api/task/{id}/", span.Name())
+	assert.Equal(t, trace.SpanKindClient, span.SpanKind())
+	assert.Equal(t, parent.SpanContext().SpanID(), span.Parent().SpanID())
+	assert.Equal(t, codes.Unset, span.Status().Code)
+	attrs := spanAttributes(span)
+	assert.Equal(t, "GET", attrs["http.request.method"].AsString())
+	assert.Equal(t, server.URL+"/api/task/1/", attrs["url.full"].AsString())
+	assert.Equal(t, int64(200), attrs["http.response.status_code"].AsInt64())
+	assert.Equal(t, int64(1), attrs["http.request.resend_count"].AsInt64())
+	assert.Len(t, span.Events(), 1)
+
+	// the server sees the span of the request as the parent of its own
+	want := "00-" + span.SpanContext().TraceID().String() + "-" + span.SpanContext().SpanID().String() + "-01"
+	assert.Equal(t, want, traceparent.Load())
+}
+
+func TestRequestSpanError(t *testing.T) {
+	server := setupTestServer(t, http.MethodDelete, map[string]string{"message": "task not found"}, http.StatusNotFound)
+	defer server.Close()
+	tp, recorder := newSpanRecorder()
+
+	err := NewClient(server.URL, WithTracerProvider(tp)).DeleteTask(context.Background(), 1)
+
+	assert.ErrorIs(t, err, ErrNotFound)
+	spans := recorder.Ended()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, int64(404), spanAttributes(spans[0])["http.response.status_code"].AsInt64())
+}
+
+func TestSpanName(t *testing.T) {
+	assert.Equal(t, "POST /api/task/", spanName(http.MethodPost, TASK_URI))
+	assert.Equal(t, "PATCH /api/task/{id}/", spanName(http.MethodPatch, taskPath(42)))
+	assert.Equal(t, "GET /api/version/", spanName(http.MethodGet, VERSION_URI))
+}
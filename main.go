@@ -4,6 +4,7 @@ import (
 	"context"
 	"flag"
 	"log"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 
@@ -17,6 +18,11 @@ var (
 	version string = "dev"
 )
 
+// shutdownTimeout bounds the export of the remaining spans on exit: Terraform kills the
+// provider about 2 seconds after asking it to stop, while the OTLP exporter retries for
+// up to a minute when the collector is unreachable.
+const shutdownTimeout = time.Second
+
 func main() {
 	var debug bool
 
@@ -28,7 +34,25 @@ func main() {
 		Debug:   debug,
 	}
 
-	err := providerserver.Serve(context.Background(), provider.New(version), opts)
+	shutdownTracing, err := provider.InitTracing(context.Background(), version)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	clientOptions, writeMetrics := provider.InitMetrics()
+
+	err = providerserver.Serve(context.Background(), provider.New(version, clientOptions...), opts)
+
+	// flush the metrics and spans before exiting, also when serving failed. The metrics
+	// are written first, as they do not depend on a collector being reachable.
+	if writeErr := writeMetrics(); writeErr != nil {
+		log.Printf("failed to write metrics: %s", writeErr)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	if shutdownErr := shutdownTracing(ctx); shutdownErr != nil {
+		log.Printf("failed to export traces: %s", shutdownErr)
+	}
+	cancel()
 
 	if err != nil {
 		log.Fatal(err.Error())